@@ -0,0 +1,67 @@
+/*
+Copyright © 2020-2023 Dmitry Mozzherin <dmozzherin@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gnames/gnames/internal/io/pgio"
+	"github.com/gnames/gnames/internal/io/srchio"
+	"github.com/gnames/gnames/internal/io/verifio"
+	"github.com/gnames/gnames/internal/io/vernio"
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/pg"
+)
+
+// newGNames connects to the database and creates GNames instance. The
+// database connection has to be closed after GNames is closed.
+func newGNames(
+	cfg config.Config,
+	gnOpts ...gnames.Option,
+) (gnames.GNames, pg.PG, error) {
+	db, err := pgio.New(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create DB connection: %w", err)
+	}
+
+	vf, err := verifio.New(cfg, db)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("cannot create verifier service: %w", err)
+	}
+
+	vern := vernio.New(cfg, db)
+
+	srch, err := srchio.New(cfg, db)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("cannot create facet search service: %w", err)
+	}
+
+	gn, err := gnames.New(cfg, vf, vern, srch, gnOpts...)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("cannot initialize gnames: %w", err)
+	}
+	return gn, db, nil
+}
@@ -22,14 +22,14 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/gnames/gnames/internal/io/pgio"
+	"github.com/gnames/gnames/internal/io/matcher"
 	"github.com/gnames/gnames/internal/io/rest"
-	"github.com/gnames/gnames/internal/io/srchio"
-	"github.com/gnames/gnames/internal/io/verifio"
-	"github.com/gnames/gnames/internal/io/vernio"
 	"github.com/gnames/gnames/internal/logr"
 	gnames "github.com/gnames/gnames/pkg"
 	"github.com/gnames/gnames/pkg/config"
@@ -58,34 +58,37 @@ var restCmd = &cobra.Command{
 		opts = append(opts, config.OptGNPort(port))
 
 		cfg := config.New(opts...)
-		db, err := pgio.New(cfg)
-		if err != nil {
-			slog.Error("Cannot create DB connection", "error", err)
-			os.Exit(1)
+
+		// the embedded matcher loads its caches in the background,
+		// readiness of the service is reported by /readyz
+		var gnOpts []gnames.Option
+		if cfg.MatcherURL == "" {
+			gnOpts = append(gnOpts,
+				gnames.WithMatcher(matcher.NewLibAsync(cfg)))
 		}
 
-		vf, err := verifio.New(cfg, db)
+		gn, db, err := newGNames(cfg, gnOpts...)
 		if err != nil {
-			slog.Error("Cannot create verifier service", "error", err)
+			slog.Error("Cannot start gnames", "error", err)
 			os.Exit(1)
 		}
 
-		vern := vernio.New(cfg, db)
+		ctx, stop := signal.NotifyContext(
+			context.Background(), syscall.SIGINT, syscall.SIGTERM,
+		)
+		defer stop()
 
-		srch, err := srchio.New(cfg, db)
+		err = rest.Run(ctx, gn, port)
 		if err != nil {
-			slog.Error("Cannot create facet search service", "error", err)
-			os.Exit(1)
+			slog.Error("HTTP API server failed", "error", err)
 		}
-
-		gn, err := gnames.New(cfg, vf, vern, srch)
+		if err := gn.Close(); err != nil {
+			slog.Error("Cannot close matcher", "error", err)
+		}
+		db.Close()
 		if err != nil {
-			slog.Error("Cannot initialize gnames", "error", err)
 			os.Exit(1)
 		}
-
-		rest.Run(gn, port)
-		os.Exit(0)
 	},
 }
 
@@ -58,6 +58,21 @@ type cfgData struct {
 	PgPort        int
 	PgUser        string
 	Port          int
+
+	DataSourcesReloadMin int
+	ShutdownTimeoutSec   int
+	AdminToken           string
+
+	APIKeys           []gncnf.APIKey
+	APIKeysFile       string
+	PublicDataSources []int
+	TrustedProxies    []string
+
+	MaxNamesPerRequest         int
+	MaxConcurrentVerifications int
+	RateLimitVerify            int
+	RateLimitSearch            int
+	RateLimitReconcile         int
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -122,6 +137,19 @@ func initConfig() {
 	_ = viper.BindEnv("PgPass", "GN_PG_PASS")
 	_ = viper.BindEnv("PgDB", "GN_PG_DB")
 	_ = viper.BindEnv("Port", "GN_PORT")
+	_ = viper.BindEnv("DataSourcesReloadMin", "GN_DATA_SOURCES_RELOAD_MIN")
+	_ = viper.BindEnv("ShutdownTimeoutSec", "GN_SHUTDOWN_TIMEOUT_SEC")
+	_ = viper.BindEnv("AdminToken", "GN_ADMIN_TOKEN")
+	_ = viper.BindEnv("APIKeysFile", "GN_API_KEYS_FILE")
+	_ = viper.BindEnv("PublicDataSources", "GN_PUBLIC_DATA_SOURCES")
+	_ = viper.BindEnv("TrustedProxies", "GN_TRUSTED_PROXIES")
+	_ = viper.BindEnv("MaxNamesPerRequest", "GN_MAX_NAMES_PER_REQUEST")
+	_ = viper.BindEnv(
+		"MaxConcurrentVerifications", "GN_MAX_CONCURRENT_VERIFICATIONS",
+	)
+	_ = viper.BindEnv("RateLimitVerify", "GN_RATE_LIMIT_VERIFY")
+	_ = viper.BindEnv("RateLimitSearch", "GN_RATE_LIMIT_SEARCH")
+	_ = viper.BindEnv("RateLimitReconcile", "GN_RATE_LIMIT_RECONCILE")
 
 	viper.AutomaticEnv() // read in environment variables that match
 
@@ -180,6 +208,47 @@ func getOpts() []gncnf.Option {
 	if cfg.GnamesHostURL != "" {
 		opts = append(opts, gncnf.OptGnamesHostURL(cfg.GnamesHostURL))
 	}
+	if cfg.DataSourcesReloadMin != 0 {
+		opts = append(opts,
+			gncnf.OptDataSourcesReloadMin(cfg.DataSourcesReloadMin))
+	}
+	if cfg.ShutdownTimeoutSec != 0 {
+		opts = append(opts,
+			gncnf.OptShutdownTimeoutSec(cfg.ShutdownTimeoutSec))
+	}
+	if cfg.AdminToken != "" {
+		opts = append(opts, gncnf.OptAdminToken(cfg.AdminToken))
+	}
+	if len(cfg.APIKeys) > 0 {
+		opts = append(opts, gncnf.OptAPIKeys(cfg.APIKeys))
+	}
+	if cfg.APIKeysFile != "" {
+		opts = append(opts, gncnf.OptAPIKeysFile(cfg.APIKeysFile))
+	}
+	if len(cfg.PublicDataSources) > 0 {
+		opts = append(opts, gncnf.OptPublicDataSources(cfg.PublicDataSources))
+	}
+	if len(cfg.TrustedProxies) > 0 {
+		opts = append(opts, gncnf.OptTrustedProxies(cfg.TrustedProxies))
+	}
+	if cfg.MaxNamesPerRequest != 0 {
+		opts = append(opts,
+			gncnf.OptMaxNamesPerRequest(cfg.MaxNamesPerRequest))
+	}
+	if cfg.MaxConcurrentVerifications != 0 {
+		opts = append(opts,
+			gncnf.OptMaxConcurrentVerifications(cfg.MaxConcurrentVerifications))
+	}
+	if cfg.RateLimitVerify != 0 {
+		opts = append(opts, gncnf.OptRateLimitVerify(cfg.RateLimitVerify))
+	}
+	if cfg.RateLimitSearch != 0 {
+		opts = append(opts, gncnf.OptRateLimitSearch(cfg.RateLimitSearch))
+	}
+	if cfg.RateLimitReconcile != 0 {
+		opts = append(opts,
+			gncnf.OptRateLimitReconcile(cfg.RateLimitReconcile))
+	}
 	return opts
 }
 
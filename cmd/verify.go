@@ -0,0 +1,206 @@
+/*
+Copyright © 2020-2023 Dmitry Mozzherin <dmozzherin@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gnames/gnames/internal/io/dwcaio"
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/flat"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [names...]",
+	Short: "Verifies scientific names from command line, a file or STDIN.",
+	Long: `Verifies scientific names without running the HTTP service.
+
+Names are taken from arguments, from a file given by the --input flag or
+from STDIN, one name per line. Results are written in JSON, CSV, TSV, XML
+or as a Darwin Core Archive (dwca). Darwin Core Archive is a zip file, so
+it requires the --output flag.`,
+	Example: `  gnames verify "Bubo bubo" "Pomatomus saltatrix"
+  gnames verify -i names.txt -f dwca -o names-dwca.zip`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runVerify(cmd, args); err != nil {
+			slog.Error("Cannot verify names", "error", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringP("input", "i", "",
+		"file with names, one name per line")
+	verifyCmd.Flags().StringP("output", "o", "",
+		"output file, STDOUT if not given")
+	verifyCmd.Flags().StringP("format", "f", "json",
+		"output format: json, csv, tsv, xml or dwca")
+	verifyCmd.Flags().StringP("data_sources", "s", "",
+		"comma-separated IDs, UUIDs or short titles of data-sources")
+	verifyCmd.Flags().BoolP("all_matches", "M", false,
+		"return all matched records")
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	input, _ := cmd.Flags().GetString("input")
+	output, _ := cmd.Flags().GetString("output")
+	format, _ := cmd.Flags().GetString("format")
+	sources, _ := cmd.Flags().GetString("data_sources")
+	allMatches, _ := cmd.Flags().GetBool("all_matches")
+
+	format = strings.ToLower(format)
+	switch format {
+	case "json", "csv", "tsv", "xml", "dwca":
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+	if format == "dwca" && output == "" {
+		return errors.New("Darwin Core Archive requires the --output flag")
+	}
+
+	names, err := readNames(args, input)
+	if err != nil {
+		return fmt.Errorf("cannot read names: %w", err)
+	}
+	if len(names) == 0 {
+		return errors.New("no names to verify")
+	}
+
+	var ds dsrc.Identifiers
+	for v := range strings.SplitSeq(sources, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ds = append(ds, v)
+		}
+	}
+
+	cfg := config.New(opts...)
+	gn, db, err := newGNames(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	defer gn.Close()
+
+	inp := verif.Input{
+		Input: vlib.Input{
+			NameStrings:    names,
+			WithAllMatches: allMatches,
+		},
+		DataSources: ds,
+	}
+	out, err := gn.VerifyWithOptions(context.Background(), inp)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("cannot create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	return writeVerified(w, gn, out, format, allMatches)
+}
+
+// readNames returns names from arguments, a file, or STDIN. Empty lines
+// are ignored.
+func readNames(args []string, input string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	r := io.Reader(os.Stdin)
+	if input != "" {
+		f, err := os.Open(input)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var res []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if name := strings.TrimSpace(sc.Text()); name != "" {
+			res = append(res, name)
+		}
+	}
+	return res, sc.Err()
+}
+
+// writeVerified writes results of verification in the given format.
+func writeVerified(
+	w io.Writer,
+	gn gnames.GNames,
+	out verif.Output,
+	format string,
+	allMatches bool,
+) error {
+	names := make([]vlib.Name, len(out.Names))
+	for i := range out.Names {
+		names[i] = out.Names[i].Name
+	}
+
+	var err error
+	switch format {
+	case "csv":
+		_, err = w.Write(flat.CSV(flat.Records(names, allMatches), ','))
+	case "tsv":
+		_, err = w.Write(flat.CSV(flat.Records(names, allMatches), '\t'))
+	case "xml":
+		var bs []byte
+		if bs, err = flat.XML(flat.Records(names, allMatches)); err == nil {
+			_, err = w.Write(bs)
+		}
+	case "dwca":
+		err = dwcaio.Write(w, out, gn.DataSources)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(out)
+	}
+	if err != nil {
+		return fmt.Errorf("cmd.writeVerified: %w", err)
+	}
+	return nil
+}
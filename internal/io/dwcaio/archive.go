@@ -0,0 +1,258 @@
+package dwcaio
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gnames/gnames/pkg/ent/dwca"
+)
+
+// File names of the archive.
+const (
+	metaFile       = "meta.xml"
+	emlFile        = "eml.xml"
+	taxonFile      = "taxon.txt"
+	vernacularFile = "vernacularname.txt"
+)
+
+// Row types and namespaces of terms.
+const (
+	rowTaxon      = "http://rs.tdwg.org/dwc/terms/Taxon"
+	rowVernacular = "http://rs.gbif.org/terms/1.0/VernacularName"
+	nsDwC         = "http://rs.tdwg.org/dwc/terms/"
+	nsDCTerms     = "http://purl.org/dc/terms/"
+)
+
+// column is a column of a data file and its Darwin Core term. The ID
+// column has no term.
+type column[T any] struct {
+	term  string
+	value func(T) string
+}
+
+var taxonColumns = []column[dwca.Taxon]{
+	{"", func(t dwca.Taxon) string { return t.ID }},
+	{nsDwC + "taxonID", func(t dwca.Taxon) string { return t.TaxonID }},
+	{nsDwC + "scientificNameID", func(t dwca.Taxon) string { return t.ScientificNameID }},
+	{nsDwC + "scientificName", func(t dwca.Taxon) string { return t.ScientificName }},
+	{nsDwC + "acceptedNameUsageID", func(t dwca.Taxon) string { return t.AcceptedNameUsageID }},
+	{nsDwC + "acceptedNameUsage", func(t dwca.Taxon) string { return t.AcceptedNameUsage }},
+	{nsDwC + "taxonomicStatus", func(t dwca.Taxon) string { return t.TaxonomicStatus }},
+	{nsDwC + "taxonRank", func(t dwca.Taxon) string { return t.TaxonRank }},
+	{nsDwC + "higherClassification", func(t dwca.Taxon) string { return t.HigherClassification }},
+	{nsDwC + "kingdom", func(t dwca.Taxon) string { return t.Kingdom }},
+	{nsDwC + "phylum", func(t dwca.Taxon) string { return t.Phylum }},
+	{nsDwC + "class", func(t dwca.Taxon) string { return t.Class }},
+	{nsDwC + "order", func(t dwca.Taxon) string { return t.Order }},
+	{nsDwC + "family", func(t dwca.Taxon) string { return t.Family }},
+	{nsDwC + "genus", func(t dwca.Taxon) string { return t.Genus }},
+	{nsDwC + "datasetID", func(t dwca.Taxon) string { return t.DatasetID }},
+	{nsDwC + "datasetName", func(t dwca.Taxon) string { return t.DatasetName }},
+	{nsDCTerms + "references", func(t dwca.Taxon) string { return t.References }},
+	{nsDwC + "verbatimIdentification", func(t dwca.Taxon) string { return t.VerbatimIdentification }},
+	{nsDwC + "taxonRemarks", func(t dwca.Taxon) string { return t.TaxonRemarks }},
+}
+
+var vernacularColumns = []column[dwca.Vernacular]{
+	{"", func(v dwca.Vernacular) string { return v.ID }},
+	{nsDwC + "vernacularName", func(v dwca.Vernacular) string { return v.VernacularName }},
+	{nsDCTerms + "language", func(v dwca.Vernacular) string { return v.Language }},
+	{nsDwC + "countryCode", func(v dwca.Vernacular) string { return v.CountryCode }},
+	{nsDwC + "locality", func(v dwca.Vernacular) string { return v.Locality }},
+	{"http://rs.gbif.org/terms/1.0/isPreferredName", func(v dwca.Vernacular) string {
+		if v.IsPreferredName {
+			return "true"
+		}
+		return ""
+	}},
+}
+
+// WriteArchive saves the archive as a zip file. The vernacular names file
+// is created only if there are vernacular names.
+func WriteArchive(w io.Writer, a dwca.Archive) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{metaFile, func(w io.Writer) error { return writeMeta(w, a) }},
+		{emlFile, func(w io.Writer) error { return writeEML(w, a.Meta) }},
+		{taxonFile, func(w io.Writer) error {
+			return writeData(w, taxonColumns, a.Taxa)
+		}},
+	}
+	if len(a.Vernaculars) > 0 {
+		files = append(files, struct {
+			name  string
+			write func(io.Writer) error
+		}{vernacularFile, func(w io.Writer) error {
+			return writeData(w, vernacularColumns, a.Vernaculars)
+		}})
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("dwcaio.WriteArchive: %w", err)
+		}
+		if err = f.write(fw); err != nil {
+			return fmt.Errorf("dwcaio.WriteArchive %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("dwcaio.WriteArchive: %w", err)
+	}
+	return nil
+}
+
+// writeData writes a tab-separated file with a header. Tabs and new lines
+// inside of values are replaced by spaces, because the archive does not
+// use quotes.
+func writeData[T any](w io.Writer, cols []column[T], rows []T) error {
+	fields := make([]string, len(cols))
+	for i, c := range cols {
+		fields[i] = "id"
+		if c.term != "" {
+			fields[i] = c.term[strings.LastIndex(c.term, "/")+1:]
+		}
+	}
+	if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+		return err
+	}
+
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	for _, r := range rows {
+		for i, c := range cols {
+			fields[i] = clean.Replace(c.value(r))
+		}
+		if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type archiveXML struct {
+	XMLName    xml.Name  `xml:"archive"`
+	XMLNS      string    `xml:"xmlns,attr"`
+	Metadata   string    `xml:"metadata,attr"`
+	Core       fileXML   `xml:"core"`
+	Extensions []fileXML `xml:"extension,omitempty"`
+}
+
+type fileXML struct {
+	Encoding           string     `xml:"encoding,attr"`
+	FieldsTerminatedBy string     `xml:"fieldsTerminatedBy,attr"`
+	LinesTerminatedBy  string     `xml:"linesTerminatedBy,attr"`
+	FieldsEnclosedBy   string     `xml:"fieldsEnclosedBy,attr"`
+	IgnoreHeaderLines  int        `xml:"ignoreHeaderLines,attr"`
+	RowType            string     `xml:"rowType,attr"`
+	Location           string     `xml:"files>location"`
+	ID                 *indexXML  `xml:"id,omitempty"`
+	CoreID             *indexXML  `xml:"coreid,omitempty"`
+	Fields             []fieldXML `xml:"field"`
+}
+
+type indexXML struct {
+	Index int `xml:"index,attr"`
+}
+
+type fieldXML struct {
+	Index int    `xml:"index,attr"`
+	Term  string `xml:"term,attr"`
+}
+
+func newFileXML[T any](location, rowType string, cols []column[T]) fileXML {
+	res := fileXML{
+		Encoding:           "UTF-8",
+		FieldsTerminatedBy: `\t`,
+		LinesTerminatedBy:  `\n`,
+		IgnoreHeaderLines:  1,
+		RowType:            rowType,
+		Location:           location,
+	}
+	for i, c := range cols {
+		if c.term != "" {
+			res.Fields = append(res.Fields, fieldXML{Index: i, Term: c.term})
+		}
+	}
+	return res
+}
+
+func writeMeta(w io.Writer, a dwca.Archive) error {
+	doc := archiveXML{
+		XMLNS:    "http://rs.tdwg.org/dwc/text/",
+		Metadata: emlFile,
+		Core:     newFileXML(taxonFile, rowTaxon, taxonColumns),
+	}
+	doc.Core.ID = &indexXML{}
+	if len(a.Vernaculars) > 0 {
+		ext := newFileXML(vernacularFile, rowVernacular, vernacularColumns)
+		ext.CoreID = &indexXML{}
+		doc.Extensions = append(doc.Extensions, ext)
+	}
+	return writeXML(w, doc)
+}
+
+type emlXML struct {
+	XMLName   xml.Name   `xml:"eml:eml"`
+	XMLNSEML  string     `xml:"xmlns:eml,attr"`
+	PackageID string     `xml:"packageId,attr"`
+	System    string     `xml:"system,attr"`
+	Dataset   datasetXML `xml:"dataset"`
+}
+
+type datasetXML struct {
+	Title    string   `xml:"title"`
+	Creator  string   `xml:"creator>organizationName"`
+	PubDate  string   `xml:"pubDate"`
+	Abstract []string `xml:"abstract>para"`
+
+	// Sources are data-sources of the records.
+	Sources []string `xml:"methods>sampling>studyExtent>description>para,omitempty"`
+}
+
+func writeEML(w io.Writer, m dwca.Meta) error {
+	title := m.Title
+	if title == "" {
+		title = "Verified scientific names"
+	}
+	doc := emlXML{
+		XMLNSEML:  "eml://ecoinformatics.org/eml-2.1.1",
+		PackageID: "gnames-" + m.Date.UTC().Format("20060102T150405Z"),
+		System:    "https://globalnames.org",
+		Dataset: datasetXML{
+			Title:   title,
+			Creator: m.Creator,
+			PubDate: m.Date.Format("2006-01-02"),
+			Abstract: []string{
+				"Scientific names verified against data-sources aggregated " +
+					"by Global Names. Every record contains the input " +
+					"name-string (verbatimIdentification) and its best match.",
+			},
+		},
+	}
+	for _, ds := range m.DataSources {
+		doc.Dataset.Sources = append(doc.Dataset.Sources,
+			fmt.Sprintf("%d: %s", ds.ID, ds.Title),
+		)
+	}
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,34 @@
+// Package dwcaio saves results of verification as a Darwin Core Archive.
+// The content of the archive is created by the dwca package, dwcaio
+// packs it into a zip file.
+package dwcaio
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/dwca"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Write saves the output of verification as a zip file of a Darwin Core
+// Archive. The dataSources function provides metadata of data-sources
+// by their IDs, usually it is the DataSources method of GNames.
+func Write(
+	w io.Writer,
+	out verif.Output,
+	dataSources func(ids ...int) []*vlib.DataSource,
+) error {
+	meta := dwca.Meta{Creator: "GNames " + gnames.Version, Date: time.Now()}
+	// without IDs dataSources returns all data-sources
+	if ids := dwca.DataSourceIDs(out); len(ids) > 0 {
+		meta.DataSources = dataSources(ids...)
+	}
+	if err := WriteArchive(w, dwca.New(out, meta)); err != nil {
+		return fmt.Errorf("dwcaio.Write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package dwcaio_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnames/gnames/internal/io/dwcaio"
+	"github.com/gnames/gnames/pkg/ent/dwca"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func output() verif.Output {
+	bubo := vlib.Name{
+		ID:        "id1",
+		Name:      "Bubo bubo",
+		MatchType: vlib.Exact,
+		BestResult: &vlib.ResultData{
+			DataSourceID:           1,
+			DataSourceTitleShort:   "Catalogue of Life",
+			RecordID:               "3FDQ",
+			MatchedName:            "Bubo bubo (Linnaeus, 1758)",
+			CurrentRecordID:        "3FDQ",
+			CurrentName:            "Bubo bubo (Linnaeus, 1758)",
+			CurrentCanonicalSimple: "Bubo bubo",
+			TaxonomicStatus:        vlib.AcceptedTaxStatus,
+			ClassificationPath:     "Animalia|Chordata|Aves|Strigiformes|Strigidae|Bubo|Bubo bubo",
+			ClassificationRanks:    "kingdom|phylum|class|order|family|genus|species",
+			MatchType:              vlib.Exact,
+		},
+	}
+	return verif.Output{Names: []verif.Name{
+		{
+			Name: bubo,
+			Vernaculars: []vern.Vernacular{
+				{Name: "Eurasian eagle-owl", LanguageCode: "eng", Preferred: true},
+			},
+		},
+		{Name: bubo},
+		{Name: vlib.Name{ID: "id2", Name: "Nothing here", MatchType: vlib.NoMatch}},
+	}}
+}
+
+func TestWriteArchive(t *testing.T) {
+	assert := assert.New(t)
+	a := dwca.New(output(), dwca.Meta{
+		Creator:     "GNames v1",
+		Date:        time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		DataSources: []*vlib.DataSource{{ID: 1, Title: "Catalogue of Life"}},
+	})
+	var buf bytes.Buffer
+	require.NoError(t, dwcaio.WriteArchive(&buf, a))
+
+	files := readZip(t, buf.Bytes())
+	assert.Len(files, 4)
+
+	var meta struct {
+		Core struct {
+			RowType string `xml:"rowType,attr"`
+			Files   string `xml:"files>location"`
+			Fields  []struct {
+				Index int    `xml:"index,attr"`
+				Term  string `xml:"term,attr"`
+			} `xml:"field"`
+		} `xml:"core"`
+		Extensions []struct {
+			Files string `xml:"files>location"`
+		} `xml:"extension"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(files["meta.xml"]), &meta))
+	assert.Equal("http://rs.tdwg.org/dwc/terms/Taxon", meta.Core.RowType)
+	assert.Equal("taxon.txt", meta.Core.Files)
+	assert.Equal("http://rs.tdwg.org/dwc/terms/scientificName",
+		meta.Core.Fields[2].Term)
+	require.Len(t, meta.Extensions, 1)
+	assert.Equal("vernacularname.txt", meta.Extensions[0].Files)
+
+	lines := strings.Split(strings.TrimSpace(files["taxon.txt"]), "\n")
+	assert.Len(lines, 3)
+	header := strings.Split(lines[0], "\t")
+	row := strings.Split(lines[1], "\t")
+	assert.Len(row, len(header))
+	assert.Equal("scientificName", header[meta.Core.Fields[2].Index])
+	assert.Equal("Bubo bubo (Linnaeus, 1758)", row[meta.Core.Fields[2].Index])
+
+	assert.Contains(files["eml.xml"], "<pubDate>2026-01-02</pubDate>")
+	assert.Contains(files["eml.xml"], "1: Catalogue of Life")
+	assert.Contains(files["vernacularname.txt"], "Eurasian eagle-owl\teng")
+}
+
+func TestWrite(t *testing.T) {
+	assert := assert.New(t)
+	var ids []int
+	dataSources := func(v ...int) []*vlib.DataSource {
+		ids = v
+		return []*vlib.DataSource{{ID: 1, Title: "Catalogue of Life"}}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, dwcaio.Write(&buf, output(), dataSources))
+	assert.Equal([]int{1}, ids)
+
+	files := readZip(t, buf.Bytes())
+	assert.Contains(files["eml.xml"], "1: Catalogue of Life")
+	assert.Contains(files["eml.xml"], "GNames v")
+}
+
+func readZip(t *testing.T, bs []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(bs), int64(len(bs)))
+	require.NoError(t, err)
+	res := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		res[f.Name] = string(data)
+	}
+	return res
+}
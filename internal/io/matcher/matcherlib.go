@@ -1,23 +1,84 @@
 package matcher
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	gncfg "github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnlib/ent/gnvers"
+	mlib "github.com/gnames/gnlib/ent/matcher"
 	gnmatcher "github.com/gnames/gnmatcher/pkg"
 	gnmcfg "github.com/gnames/gnmatcher/pkg/config"
-	gncfg "github.com/gnames/gnames/pkg/config"
 )
 
+// ErrInitializing means that the embedded matcher is still building or
+// loading its caches.
+var ErrInitializing = errors.New("matcher is initializing")
+
+// matcherLib wraps an embedded gnmatcher instance. It allows to replace
+// the instance when its caches are rebuilt.
+type matcherLib struct {
+	cfg gncfg.Config
+	mu  sync.RWMutex
+	gnm gnmatcher.GNmatcher
+	// dir is the cache directory of the current gnmatcher instance.
+	dir string
+
+	// rebuildMu allows only one rebuild of caches at a time.
+	rebuildMu sync.Mutex
+
+	// initErr is nil when the matcher is ready to use.
+	initErr atomic.Pointer[error]
+}
+
 // NewLib creates an embedded gnmatcher instance, initialises it, and returns
 // it. An error is returned if initialisation fails (e.g. DB unreachable).
+// The instance also implements admin.CacheManager.
 func NewLib(cfg gncfg.Config) (gnmatcher.GNmatcher, error) {
-	gnm := gnmatcher.New(toMatcherConfig(cfg))
-	return gnm, gnm.Init()
+	dir := currentCacheDir(cfg)
+	gnm := gnmatcher.New(toMatcherConfig(cfg, dir))
+	res := &matcherLib{cfg: cfg, gnm: gnm, dir: dir}
+	err := gnm.Init()
+	res.setInitErr(err)
+	return res, err
 }
 
-func toMatcherConfig(cfg gncfg.Config) gnmcfg.Config {
+// NewLibAsync creates an embedded gnmatcher instance and initialises it in
+// the background. It allows to start a service without waiting for the
+// caches to load. Matching requests wait until the initialisation is
+// finished, InitErr reports its progress.
+func NewLibAsync(cfg gncfg.Config) gnmatcher.GNmatcher {
+	dir := currentCacheDir(cfg)
+	res := &matcherLib{
+		cfg: cfg,
+		gnm: gnmatcher.New(toMatcherConfig(cfg, dir)),
+		dir: dir,
+	}
+	res.setInitErr(ErrInitializing)
+	res.mu.Lock()
+	go func() {
+		defer res.mu.Unlock()
+		err := res.gnm.Init()
+		if err != nil {
+			slog.Error("Cannot initialize embedded matcher", "error", err)
+		}
+		res.setInitErr(err)
+	}()
+	return res
+}
+
+func toMatcherConfig(cfg gncfg.Config, dir string) gnmcfg.Config {
 	return gnmcfg.New(
-		gnmcfg.OptCacheDir(filepath.Join(cfg.CacheDir, "gnmatcher")),
+		gnmcfg.OptCacheDir(dir),
 		gnmcfg.OptJobsNum(cfg.JobsNum),
 		gnmcfg.OptMaxEditDist(cfg.MaxEditDist),
 		gnmcfg.OptPgHost(cfg.PgHost),
@@ -27,3 +88,189 @@ func toMatcherConfig(cfg gncfg.Config) gnmcfg.Config {
 		gnmcfg.OptPgDB(cfg.PgDB),
 	)
 }
+
+// matcherCacheDir is the default cache directory of the matcher. After
+// a rebuild of caches it is a symlink to the directory with new caches.
+func matcherCacheDir(cfg gncfg.Config) string {
+	return filepath.Join(cfg.CacheDir, "gnmatcher")
+}
+
+// currentCacheDir resolves the symlink of the default cache directory,
+// if there is one.
+func currentCacheDir(cfg gncfg.Config) string {
+	dir := matcherCacheDir(cfg)
+	if res, err := filepath.EvalSymlinks(dir); err == nil {
+		return res
+	}
+	return dir
+}
+
+// linkCacheDir points the default cache directory to dir, so new caches
+// are used after a restart as well. The default directory must not be
+// a directory anymore, it is either missing or a symlink.
+func linkCacheDir(cfg gncfg.Config, dir string) error {
+	link := matcherCacheDir(cfg)
+	tmp := link + ".new"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(filepath.Base(dir), tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp, link)
+}
+
+// InitErr returns nil if the matcher is ready, ErrInitializing while
+// its caches are loaded or rebuilt, or the error of the initialisation.
+func (m *matcherLib) InitErr() error {
+	if err := m.initErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (m *matcherLib) setInitErr(err error) {
+	if err == nil {
+		m.initErr.Store(nil)
+		return
+	}
+	m.initErr.Store(&err)
+}
+
+func (m *matcherLib) Init() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.gnm.Init()
+}
+
+func (m *matcherLib) MatchNames(
+	names []string,
+	opts ...gnmcfg.Option,
+) mlib.Output {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.gnm.MatchNames(names, opts...)
+}
+
+func (m *matcherLib) GetConfig() gnmcfg.Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.gnm.GetConfig()
+}
+
+func (m *matcherLib) GetVersion() gnvers.Version {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.gnm.GetVersion()
+}
+
+// Close waits until running matches are finished and releases resources
+// of the embedded matcher.
+func (m *matcherLib) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.gnm.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("matcher.Close: %w", err)
+		}
+	}
+	return nil
+}
+
+// CacheInfo returns sizes and build times of every cache (bloom filters,
+// trie, stems key-value store) of the embedded matcher.
+func (m *matcherLib) CacheInfo() ([]admin.CacheInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	dir := m.dir
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("matcher.CacheInfo: %w", err)
+	}
+
+	var res []admin.CacheInfo
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		ci, err := cacheInfo(e.Name(), filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("matcher.CacheInfo: %w", err)
+		}
+		res = append(res, ci)
+	}
+	return res, nil
+}
+
+// RebuildCache creates caches of the embedded matcher from the database
+// in a new directory. Matching uses the old caches until the new ones are
+// ready. Then the old instance is closed and its caches are removed. If
+// the rebuild fails, the old instance keeps working.
+func (m *matcherLib) RebuildCache() error {
+	m.rebuildMu.Lock()
+	defer m.rebuildMu.Unlock()
+
+	err := os.MkdirAll(m.cfg.CacheDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("matcher.RebuildCache: %w", err)
+	}
+	dir, err := os.MkdirTemp(m.cfg.CacheDir, "gnmatcher-")
+	if err != nil {
+		return fmt.Errorf("matcher.RebuildCache: %w", err)
+	}
+	gnm := gnmatcher.New(toMatcherConfig(m.cfg, dir))
+	if err = gnm.Init(); err != nil {
+		_ = os.RemoveAll(dir)
+		return fmt.Errorf("matcher.RebuildCache: %w", err)
+	}
+
+	m.mu.Lock()
+	old, oldDir := m.gnm, m.dir
+	m.gnm, m.dir = gnm, dir
+	m.mu.Unlock()
+	m.setInitErr(nil)
+
+	// nobody uses the old instance anymore, because running matches hold
+	// the read lock.
+	if c, ok := old.(io.Closer); ok {
+		if err = c.Close(); err != nil {
+			slog.Warn("Cannot close old matcher", "error", err)
+		}
+	}
+	if err = os.RemoveAll(oldDir); err != nil {
+		return fmt.Errorf("matcher.RebuildCache: %w", err)
+	}
+	if err = linkCacheDir(m.cfg, dir); err != nil {
+		return fmt.Errorf("matcher.RebuildCache: %w", err)
+	}
+	return nil
+}
+
+func cacheInfo(name, path string) (admin.CacheInfo, error) {
+	res := admin.CacheInfo{Name: name, Path: path}
+	var builtAt time.Time
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		res.FilesNum++
+		res.SizeBytes += info.Size()
+		if info.ModTime().After(builtAt) {
+			builtAt = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	if !builtAt.IsZero() {
+		res.BuiltAt = builtAt.UTC().Format(time.RFC3339)
+	}
+	return res, nil
+}
@@ -0,0 +1,43 @@
+package matcher_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnames/gnames/internal/io/matcher"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildCache(t *testing.T) {
+	assert := assert.New(t)
+	cfg := getConfig()
+	cfg.CacheDir = t.TempDir()
+	m, err := matcher.NewLib(cfg)
+	if err != nil {
+		t.Skipf("embedded matcher is not available: %v", err)
+	}
+	defer m.(interface{ Close() error }).Close()
+
+	oldDir := filepath.Join(cfg.CacheDir, "gnmatcher")
+	assert.Nil(os.MkdirAll(oldDir, 0o755))
+
+	cm := m.(admin.CacheManager)
+	assert.Nil(cm.RebuildCache())
+
+	// the default directory points to the new caches
+	fi, err := os.Lstat(oldDir)
+	assert.Nil(err)
+	assert.Equal(os.ModeSymlink, fi.Mode()&os.ModeSymlink)
+	dir, err := filepath.EvalSymlinks(oldDir)
+	assert.Nil(err)
+	assert.Contains(filepath.Base(dir), "gnmatcher-")
+
+	// the second rebuild removes caches of the first one
+	assert.Nil(cm.RebuildCache())
+	_, err = os.Stat(dir)
+	assert.True(os.IsNotExist(err))
+	_, err = cm.CacheInfo()
+	assert.Nil(err)
+}
@@ -11,9 +11,15 @@ func auQuery(
 	q string,
 	inp search.Input,
 	args []interface{},
+	fw fuzzyWords,
 ) (string, []interface{}) {
 	var auStr string
-	auStr, args = prepareAuWord(inp, args)
+	if fw.auVariants != nil {
+		args = append(args, fw.auVariants)
+		auStr = fmt.Sprintf("= any($%d::text[])", len(args))
+	} else {
+		auStr, args = prepareAuWord(inp, args)
+	}
 	args = append(args, int(parsed.AuthorWordType))
 	auQ := fmt.Sprintf(`
 au AS (
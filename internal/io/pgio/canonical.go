@@ -0,0 +1,94 @@
+package pgio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/jackc/pgx/v5"
+)
+
+// canonicalTables are tables of canonical forms and columns of
+// name_strings that refer to them.
+var canonicalTables = map[canonical.Kind][2]string{
+	canonical.Simple: {"canonicals", "canonical_id"},
+	canonical.Full:   {"canonical_fulls", "canonical_full_id"},
+	canonical.Stem:   {"canonical_stems", "canonical_stem_id"},
+}
+
+// canonicalQuery finds a page of records of name-strings with the
+// canonical form. Simple canonical forms use the index of the verification
+// view directly.
+func canonicalQuery(
+	id string,
+	kind canonical.Kind,
+	col string,
+	dataSources []int,
+	limit, offset int,
+) (string, []any) {
+	cond := fmt.Sprintf(`v.name_string_id IN (
+    SELECT id FROM name_strings WHERE %s = $1
+  )`, col)
+	if kind == canonical.Simple {
+		cond = "v.canonical_id = $1"
+	}
+	q := fmt.Sprintf(`
+SELECT %s
+FROM verification v
+WHERE %s`, queryFields, cond)
+	args := []any{id}
+	if len(dataSources) > 0 {
+		args = append(args, dataSources)
+		q += "\n    AND data_source_id = any($2::int[])"
+	}
+	q += "\nORDER BY v.name, v.data_source_id, v.record_id"
+	args = append(args, limit, offset)
+	return q + fmt.Sprintf("\nLIMIT $%d OFFSET $%d", len(args)-1, len(args)),
+		args
+}
+
+// CanonicalRecords finds records of all name-strings that share the
+// canonical form. It returns the canonical form and at most limit records
+// after offset. If the canonical form is unknown, it returns an empty
+// string.
+func (p *pgio) CanonicalRecords(
+	ctx context.Context,
+	id string,
+	kind canonical.Kind,
+	dataSources []int,
+	limit, offset int,
+) (string, []*vlib.ResultData, error) {
+	tbl, ok := canonicalTables[kind]
+	if !ok {
+		return "", nil, fmt.Errorf("pgio.CanonicalRecords: %w", canonical.ErrKind)
+	}
+
+	var name string
+	q := fmt.Sprintf("SELECT name FROM %s WHERE id = $1", tbl[0])
+	err := p.db.QueryRow(ctx, q, id).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("pgio.CanonicalRecords: %w", err)
+	}
+
+	q, args := canonicalQuery(id, kind, tbl[1], dataSources, limit, offset)
+	vSQL, err := p.idQueryRun(ctx, q, args)
+	if err != nil {
+		return "", nil, fmt.Errorf("pgio.CanonicalRecords: %w", err)
+	}
+
+	gnp := <-p.gnpPool
+	defer func() { p.gnpPool <- gnp }()
+
+	res := make([]*vlib.ResultData, 0, len(vSQL))
+	for _, v := range vSQL {
+		if rd, ok := p.recordData(v, gnp); ok {
+			res = append(res, &rd)
+		}
+	}
+	return name, res, nil
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-func (p *pgio) dataSources(ids ...int) ([]*vlib.DataSource, error) {
+func (p *pgio) dataSources(
+	ctx context.Context,
+	ids ...int,
+) ([]*vlib.DataSource, error) {
 	var err error
 	var rows pgx.Rows
 	var dss []*dataSource
-	ctx := context.Background()
 
 	idsWere := "WHERE id = any($1)"
 	q := `
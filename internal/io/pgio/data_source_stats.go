@@ -0,0 +1,127 @@
+package pgio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/jackc/pgx/v5"
+)
+
+// DataSourceStats computes statistics of the records of a data-source.
+// The queries go through all records of the data-source, so for large
+// data-sources they are slow, and the results should be cached.
+func (p *pgio) DataSourceStats(
+	ctx context.Context,
+	id int,
+) (dsrc.Stats, error) {
+	var err error
+	res := dsrc.Stats{DataSourceID: id}
+
+	q := `
+SELECT COALESCE(title_short, ''), COALESCE(vern_record_count, 0)
+  FROM data_sources
+  WHERE id = $1
+`
+	row := p.db.QueryRow(ctx, q, id)
+	err = row.Scan(&res.TitleShort, &res.VernacularRecordsNum)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return res, fmt.Errorf("pgio.DataSourceStats: id %d: %w", id, dsrc.ErrNotFound)
+	}
+	if err != nil {
+		return res, fmt.Errorf("pgio.DataSourceStats: %w", err)
+	}
+
+	q = `
+SELECT count(*), count(DISTINCT name_string_id),
+    count(*) FILTER (WHERE COALESCE(classification, '') <> ''),
+    count(*) FILTER (
+      WHERE COALESCE(accepted_record_id, '') IN ('', record_id)
+    )
+  FROM name_string_indices
+  WHERE data_source_id = $1
+`
+	var clNum int
+	row = p.db.QueryRow(ctx, q, id)
+	err = row.Scan(&res.RecordsNum, &res.NameStringsNum, &clNum, &res.AcceptedNum)
+	if err != nil {
+		return res, fmt.Errorf("pgio.DataSourceStats: %w", err)
+	}
+	res.SynonymsNum = res.RecordsNum - res.AcceptedNum
+	if res.RecordsNum > 0 {
+		res.ClassificationPercentage = float64(clNum) / float64(res.RecordsNum)
+	}
+
+	q = `
+SELECT COALESCE(NULLIF(lower(rank), ''), 'unknown') AS rnk, count(*)
+  FROM name_string_indices
+  WHERE data_source_id = $1
+  GROUP BY rnk
+  ORDER BY count(*) DESC, rnk
+`
+	res.Ranks, err = p.counts(ctx, q, id)
+	if err != nil {
+		return res, fmt.Errorf("pgio.DataSourceStats: ranks: %w", err)
+	}
+
+	q = `
+SELECT kingdom, count(*)
+  FROM (
+    SELECT (string_to_array(classification, '|'))[
+        array_position(string_to_array(lower(classification_ranks), '|'),
+          'kingdom')
+      ] AS kingdom
+      FROM name_string_indices
+      WHERE data_source_id = $1
+        AND classification_ranks ILIKE '%kingdom%'
+  ) k
+  WHERE COALESCE(kingdom, '') <> ''
+  GROUP BY kingdom
+  ORDER BY count(*) DESC, kingdom
+`
+	res.Kingdoms, err = p.counts(ctx, q, id)
+	if err != nil {
+		return res, fmt.Errorf("pgio.DataSourceStats: kingdoms: %w", err)
+	}
+
+	q = `
+SELECT COALESCE(NULLIF(lang_code, ''), 'unknown') AS lang, count(*)
+  FROM vernacular_string_indices
+  WHERE data_source_id = $1
+  GROUP BY lang
+  ORDER BY count(*) DESC, lang
+`
+	res.Vernaculars, err = p.counts(ctx, q, id)
+	if err != nil {
+		return res, fmt.Errorf("pgio.DataSourceStats: vernaculars: %w", err)
+	}
+
+	res.ComputedAt = time.Now().UTC().Format(time.RFC3339)
+	return res, nil
+}
+
+// counts runs a query that returns names of categories and numbers of
+// items in them.
+func (p *pgio) counts(
+	ctx context.Context,
+	q string,
+	args ...any,
+) ([]dsrc.Count, error) {
+	rows, err := p.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []dsrc.Count
+	for rows.Next() {
+		var c dsrc.Count
+		if err = rows.Scan(&c.Name, &c.Count); err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, rows.Err()
+}
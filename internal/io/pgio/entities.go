@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gnames/gnparser/ent/parsed"
 	"github.com/jackc/pgx/v5"
@@ -28,6 +29,19 @@ type verifSQL struct {
 	ClassificationRanks sql.NullString
 	ClassificationIds   sql.NullString
 	ParseQuality        int
+
+	// GlobalID is only queried by lookups of records.
+	GlobalID sql.NullString
+}
+
+// fields returns destinations for scanning of queryFields.
+func (v *verifSQL) fields() []any {
+	return []any{
+		&v.CanonicalID, &v.Name, &v.DataSourceID, &v.RecordID,
+		&v.NameStringID, &v.LocalID, &v.OutlinkID, &v.AcceptedRecordID,
+		&v.AcceptedNameID, &v.AcceptedName, &v.Classification,
+		&v.ClassificationRanks, &v.ClassificationIds, &v.ParseQuality,
+	}
 }
 
 func rowsToVerifSQL(rows pgx.Rows) ([]*verifSQL, error) {
@@ -35,12 +49,7 @@ func rowsToVerifSQL(rows pgx.Rows) ([]*verifSQL, error) {
 	var res []*verifSQL
 	for rows.Next() {
 		var v verifSQL
-		err = rows.Scan(
-			&v.CanonicalID, &v.Name, &v.DataSourceID, &v.RecordID,
-			&v.NameStringID, &v.LocalID, &v.OutlinkID, &v.AcceptedRecordID,
-			&v.AcceptedNameID, &v.AcceptedName, &v.Classification,
-			&v.ClassificationRanks, &v.ClassificationIds, &v.ParseQuality,
-		)
+		err = rows.Scan(v.fields()...)
 		if err != nil {
 			return nil, fmt.Errorf("pgio.rowsToVerifSQL: %w", err)
 		}
@@ -74,3 +83,10 @@ func processAuthorship(au *parsed.Authorship) ([]string, int) {
 	}
 	return authors, year
 }
+
+// escapeLike escapes characters that have special meaning in LIKE
+// patterns.
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
@@ -0,0 +1,170 @@
+package pgio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gnames/gnames/pkg/ent/srch"
+	"github.com/gnames/gnparser/ent/parsed"
+	"github.com/gnames/levenshtein/ent/editdist"
+)
+
+// wordCandidatesLimit is the largest number of words compared to a word of
+// a query. Words with the closest length are compared first.
+const wordCandidatesLimit = 10_000
+
+// fuzzyWords contains words from a faceted search query together with their
+// variants found in the database within the edit distance tolerance. If
+// variants are nil, the word is matched exactly.
+type fuzzyWords struct {
+	// maxEditDist is the edit distance tolerance of the search.
+	maxEditDist int
+
+	// sp is a normalized (stemmed) species or infraspecies epithet.
+	sp string
+
+	// spTypes are word types that the epithet can belong to.
+	spTypes []int
+
+	// spVariants are epithets in the database similar to sp.
+	spVariants []string
+
+	// au is a normalized author word.
+	au string
+
+	// auVariants are author words in the database similar to au.
+	auVariants []string
+}
+
+// newFuzzyWords finds variants of the species epithet and the author
+// from the search input. Words that end with a period are treated as
+// prefixes and are not matched fuzzily.
+func (p *pgio) newFuzzyWords(
+	ctx context.Context,
+	inp srch.Input,
+	spWordIDs []int,
+	spWord string,
+) (fuzzyWords, error) {
+	var err error
+	res := fuzzyWords{maxEditDist: inp.MaxEditDist, spTypes: spWordIDs}
+	if inp.MaxEditDist == 0 {
+		return res, nil
+	}
+
+	if !strings.HasSuffix(spWord, ".") {
+		res.sp = parsed.NormalizeByType(spWord, parsed.SpEpithetType)
+		res.spVariants, err = p.wordVariants(ctx, res.sp, spWordIDs, inp.MaxEditDist)
+		if err != nil {
+			return res, fmt.Errorf("pgio.newFuzzyWords: %w", err)
+		}
+	}
+
+	if inp.Author != "" && !strings.HasSuffix(inp.Author, ".") {
+		res.au = parsed.NormalizeByType(inp.Author, parsed.AuthorWordType)
+		auTypes := []int{int(parsed.AuthorWordType)}
+		res.auVariants, err = p.wordVariants(ctx, res.au, auTypes, inp.MaxEditDist)
+		if err != nil {
+			return res, fmt.Errorf("pgio.newFuzzyWords: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// wordVariants returns modified forms of words of given types that are
+// within maxEditDist from the word. To keep the query fast, only words
+// that start with the same letter are considered, errors in the first
+// letter are rare. The prefix search uses words_modified_pattern index.
+func (p *pgio) wordVariants(
+	ctx context.Context,
+	word string,
+	typeIDs []int,
+	maxEditDist int,
+) ([]string, error) {
+	res := make([]string, 0)
+	l := utf8.RuneCountInString(word)
+	if l == 0 {
+		return res, nil
+	}
+	first, _ := utf8.DecodeRuneInString(word)
+
+	q := `
+SELECT modified
+  FROM words
+  WHERE type_id = any($1::int[])
+    AND modified LIKE $2
+    AND length(modified) BETWEEN $3 AND $4
+  GROUP BY modified
+  ORDER BY abs(length(modified) - $5)
+  LIMIT $6
+`
+	rows, err := p.db.Query(
+		ctx, q, typeIDs, escapeLike(string(first))+"%",
+		l-maxEditDist, l+maxEditDist, l, wordCandidatesLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.wordVariants: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w string
+		if err = rows.Scan(&w); err != nil {
+			return nil, fmt.Errorf("pgio.wordVariants: %w", err)
+		}
+		if _, ok := editDistance(word, w, maxEditDist); ok {
+			res = append(res, w)
+		}
+	}
+	return res, rows.Err()
+}
+
+// editDistance calculates edit distance between two words. It returns
+// false if the distance is bigger than maxEditDist.
+func editDistance(w1, w2 string, maxEditDist int) (int, bool) {
+	dist, aborted := editdist.ComputeDistanceMax(w1, w2, maxEditDist)
+	if aborted || dist > maxEditDist {
+		return dist, false
+	}
+	return dist, true
+}
+
+// nameEditDistance finds how far a matched name is from the searched
+// words. It compares the closest epithet and the closest author word of
+// the name to the words of the query and returns the sum of distances.
+func (fw fuzzyWords) nameEditDistance(prsd parsed.Parsed) int {
+	if fw.maxEditDist == 0 {
+		return 0
+	}
+	spDist := -1
+	auDist := -1
+	for _, w := range prsd.Words {
+		switch {
+		case fw.sp != "" && fw.isSpType(w.Type):
+			wrd := parsed.NormalizeByType(w.Normalized, w.Type)
+			if d, ok := editDistance(fw.sp, wrd, fw.maxEditDist); ok {
+				if spDist == -1 || d < spDist {
+					spDist = d
+				}
+			}
+		case fw.au != "" && w.Type == parsed.AuthorWordType:
+			wrd := parsed.NormalizeByType(w.Normalized, w.Type)
+			if d, ok := editDistance(fw.au, wrd, fw.maxEditDist); ok {
+				if auDist == -1 || d < auDist {
+					auDist = d
+				}
+			}
+		}
+	}
+	return max(spDist, 0) + max(auDist, 0)
+}
+
+func (fw fuzzyWords) isSpType(wt parsed.WordType) bool {
+	for _, v := range fw.spTypes {
+		if int(wt) == v {
+			return true
+		}
+	}
+	return false
+}
@@ -273,7 +273,7 @@ func (p *pgio) dsData(
 	vsql *verifSQL,
 ) (*vlib.DataSource, string, string) {
 	var outlink string
-	ds, ok := p.dsm[vsql.DataSourceID]
+	ds, ok := p.reg.Get(vsql.DataSourceID)
 	if !ok || ds == nil {
 		slog.Warn("Unknown data source ID", slog.Int("dataSourceID", vsql.DataSourceID))
 		return &vlib.DataSource{}, "", ""
@@ -297,6 +297,7 @@ func (p *pgio) addVirusMatch(
 	resData := vlib.ResultData{
 		RecordID:             vsql.RecordID.String,
 		LocalID:              vsql.LocalID.String,
+		GlobalID:             vsql.GlobalID.String,
 		Outlink:              outlink,
 		DataSourceID:         vsql.DataSourceID,
 		DataSourceTitleShort: title,
@@ -351,6 +352,7 @@ func (p *pgio) addMatch(
 	resData := vlib.ResultData{
 		RecordID:               vsql.RecordID.String,
 		LocalID:                vsql.LocalID.String,
+		GlobalID:               vsql.GlobalID.String,
 		Outlink:                outlink,
 		DataSourceID:           dsID,
 		DataSourceTitleShort:   title,
@@ -383,8 +385,8 @@ func (p *pgio) addMatch(
 
 func (p pgio) hasTaxonData(vsql *verifSQL) bool {
 	var res bool
-	if _, ok := p.dsm[vsql.DataSourceID]; ok {
-		res = p.dsm[vsql.DataSourceID].HasTaxonData
+	if ds, ok := p.reg.Get(vsql.DataSourceID); ok {
+		res = ds.HasTaxonData
 	}
 	return res
 }
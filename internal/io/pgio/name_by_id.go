@@ -24,6 +24,22 @@ WHERE name_string_id = $1
 	return q, args
 }
 
+func idsQuery(ids []string, dataSources []int) (string, []any) {
+	q := fmt.Sprintf(`
+SELECT %s
+FROM verification v
+WHERE name_string_id = any($1::uuid[])
+	`, queryFields)
+
+	args := []any{ids}
+
+	if len(dataSources) > 0 {
+		args = append(args, dataSources)
+		q += "\n    AND data_source_id = any($2::int[])"
+	}
+	return q, args
+}
+
 func (p *pgio) idQueryRun(
 	ctx context.Context,
 	q string,
@@ -3,20 +3,26 @@ package pgio
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
 	"github.com/gnames/gnames/pkg/ent/pg"
+	"github.com/gnames/gnames/pkg/ent/srch"
 	"github.com/gnames/gnames/pkg/ent/verif"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/gnames/gnparser"
-	"github.com/gnames/gnquery/ent/search"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type pgio struct {
 	db      *pgxpool.Pool
-	dsm     map[int]*vlib.DataSource
+	reg     *dsrc.Registry
 	gnpPool chan gnparser.GNparser
+
+	// vernNorm is an SQL expression that normalizes vernacular names.
+	vernNorm string
 }
 
 func New(cfg config.Config) (pg.PG, error) {
@@ -25,11 +31,21 @@ func New(cfg config.Config) (pg.PG, error) {
 		return nil, fmt.Errorf("new PG instance failed: %w", err)
 	}
 
-	dsm, err := res.dataSourcesMap()
+	dss, err := res.dataSources(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("could not get data sources map: %w", err)
+		return nil, fmt.Errorf("could not get data sources: %w", err)
+	}
+	res.reg = dsrc.NewRegistry(dss)
+
+	hasVernNorm, err := res.hasVernNorm(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("could not check vernacular normalization: %w", err)
+	}
+	if !hasVernNorm {
+		slog.Warn("Function gn_vern_norm is missing, vernacular search " +
+			"does not use the index, apply migrations/vernacular_norm.sql")
 	}
-	res.dsm = dsm
+	res.vernNorm = vernNormExpr(hasVernNorm)
 
 	poolSize := 5
 	gnpPool := make(chan gnparser.GNparser, poolSize)
@@ -42,20 +58,43 @@ func New(cfg config.Config) (pg.PG, error) {
 	return res, nil
 }
 
-func (p *pgio) DataSourcesMap() map[int]*vlib.DataSource {
-	return p.dsm
+func (p *pgio) DataSources() *dsrc.Registry {
+	return p.reg
 }
 
-func (p *pgio) dataSourcesMap() (map[int]*vlib.DataSource, error) {
-	res := make(map[int]*vlib.DataSource)
-	dss, err := p.dataSources()
-	if err != nil {
-		return res, fmt.Errorf("pgio.dataSourceMap: %w", err)
+// Close closes all connections of the pool. It waits until acquired
+// connections are released.
+func (p *pgio) Close() {
+	p.db.Close()
+}
+
+// Ping checks connectivity to the database.
+func (p *pgio) Ping(ctx context.Context) error {
+	return p.db.Ping(ctx)
+}
+
+// PoolStats returns the state of the database connections pool.
+func (p *pgio) PoolStats() admin.PoolStats {
+	st := p.db.Stat()
+	return admin.PoolStats{
+		MaxConns:          int(st.MaxConns()),
+		TotalConns:        int(st.TotalConns()),
+		AcquiredConns:     int(st.AcquiredConns()),
+		IdleConns:         int(st.IdleConns()),
+		AcquireCount:      st.AcquireCount(),
+		EmptyAcquireCount: st.EmptyAcquireCount(),
+		AcquireDuration:   st.AcquireDuration().String(),
 	}
-	for _, ds := range dss {
-		res[ds.ID] = ds
+}
+
+// ReloadDataSources reads metadata of data-sources from the database
+// and replaces the content of the shared registry.
+func (p *pgio) ReloadDataSources(ctx context.Context) (dsrc.Changes, error) {
+	dss, err := p.dataSources(ctx)
+	if err != nil {
+		return dsrc.Changes{}, fmt.Errorf("pgio.ReloadDataSources: %w", err)
 	}
-	return res, nil
+	return p.reg.Replace(dss), nil
 }
 
 func (p *pgio) MatchRecordsMap(
@@ -103,6 +142,35 @@ func (p *pgio) NameByID(inp vlib.NameStringInput) (*verif.MatchRecord, error) {
 	return p.idData(vSQL), nil
 }
 
+// NamesByIDs finds name-strings by their IDs in one query.
+func (p *pgio) NamesByIDs(
+	ctx context.Context,
+	ids []string,
+	dataSources []int,
+) (map[string]*verif.MatchRecord, error) {
+	res := make(map[string]*verif.MatchRecord)
+	if len(ids) == 0 {
+		return res, nil
+	}
+	q, args := idsQuery(ids, dataSources)
+	vSQL, err := p.idQueryRun(ctx, q, args)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.NamesByIDs: %w", err)
+	}
+
+	byID := make(map[string][]*verifSQL)
+	for _, v := range vSQL {
+		id := v.NameStringID.String
+		byID[id] = append(byID[id], v)
+	}
+	for id, match := range byID {
+		if mr := p.idData(match); mr != nil {
+			res[id] = mr
+		}
+	}
+	return res, nil
+}
+
 func (p *pgio) NameStringByID(id string) (string, error) {
 	ctx := context.Background()
 
@@ -117,14 +185,46 @@ func (p *pgio) NameStringByID(id string) (string, error) {
 	return res, nil
 }
 
+func (p *pgio) NameStringsByIDs(
+	ctx context.Context,
+	ids []string,
+) (map[string]string, error) {
+	res := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+	q := "SELECT id::text, name FROM name_strings WHERE id = any($1::uuid[])"
+	rows, err := p.db.Query(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.NameStringsByIDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("pgio.NameStringsByIDs: %w", err)
+		}
+		res[id] = name
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgio.NameStringsByIDs: %w", err)
+	}
+	return res, nil
+}
+
 func (p *pgio) SearchRecordsMap(
 	ctx context.Context,
-	input search.Input,
+	input srch.Input,
 	spWordIDs []int,
 	spWord string,
 ) (map[string]*verif.MatchRecord, error) {
-	q, args := setQuery(input, spWordIDs, spWord)
-	res, err := p.runQuery(ctx, q, args)
+	fw, err := p.newFuzzyWords(ctx, input, spWordIDs, spWord)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.SearchRecordsMap: %w", err)
+	}
+	q, args := setQuery(input.Input, spWordIDs, spWord, fw)
+	res, err := p.runQuery(ctx, q, args, fw)
 	if err != nil {
 		return nil, fmt.Errorf("pgio.SearchRecordsMap: %w", err)
 	}
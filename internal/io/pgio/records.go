@@ -0,0 +1,113 @@
+package pgio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gnames/gnames/pkg/ent/record"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnparser"
+)
+
+// recordColumns are columns of name_string_indices that keep identifiers
+// of records.
+var recordColumns = map[record.IDType]string{
+	record.RecordID: "record_id",
+	record.LocalID:  "local_id",
+	record.GlobalID: "global_id",
+}
+
+// recordsQuery finds records of a data-source by their identifiers.
+// Lookups by record_id use the name_string_ids_idx index
+// (data_source_id, record_id, name_string_id). Lookups by local_id and
+// global_id use nsi_local_id_idx and nsi_global_id_idx indexes.
+func recordsQuery(col string) string {
+	return fmt.Sprintf(`
+SELECT %s, nsi.global_id
+FROM name_string_indices nsi
+  JOIN verification v
+    ON v.data_source_id = nsi.data_source_id
+      AND v.record_id = nsi.record_id
+      AND v.name_string_id = nsi.name_string_id
+WHERE nsi.data_source_id = $1
+  AND nsi.%s = any($2::varchar[])
+ORDER BY nsi.record_id, nsi.name_string_id
+`, queryFields, col)
+}
+
+// RecordsByIDs finds records of a data-source by their identifiers.
+func (p *pgio) RecordsByIDs(
+	ctx context.Context,
+	dataSourceID int,
+	idType record.IDType,
+	ids []string,
+) (map[string][]*vlib.ResultData, error) {
+	res := make(map[string][]*vlib.ResultData)
+	col, ok := recordColumns[idType]
+	if !ok {
+		return nil, fmt.Errorf("pgio.RecordsByIDs: %w", record.ErrIDType)
+	}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	rows, err := p.db.Query(ctx, recordsQuery(col), dataSourceID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.RecordsByIDs: %w", err)
+	}
+	defer rows.Close()
+
+	var vSQL []*verifSQL
+	for rows.Next() {
+		var v verifSQL
+		if err = rows.Scan(append(v.fields(), &v.GlobalID)...); err != nil {
+			return nil, fmt.Errorf("pgio.RecordsByIDs: %w", err)
+		}
+		vSQL = append(vSQL, &v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgio.RecordsByIDs: %w", err)
+	}
+
+	gnp := <-p.gnpPool
+	defer func() { p.gnpPool <- gnp }()
+
+	for _, v := range vSQL {
+		rd, ok := p.recordData(v, gnp)
+		if !ok {
+			continue
+		}
+
+		var id string
+		switch idType {
+		case record.LocalID:
+			id = v.LocalID.String
+		case record.GlobalID:
+			id = v.GlobalID.String
+		default:
+			id = v.RecordID.String
+		}
+		res[id] = append(res[id], &rd)
+	}
+	return res, nil
+}
+
+// recordData converts a record to ResultData with exact or virus match
+// type. Records with names that cannot be parsed are skipped.
+func (p *pgio) recordData(
+	v *verifSQL,
+	gnp gnparser.GNparser,
+) (vlib.ResultData, bool) {
+	prsd := gnp.ParseName(v.Name.String)
+	switch {
+	case prsd.Virus:
+		rd := p.addVirusMatch(v)
+		rd.MatchType = vlib.Virus
+		return rd, true
+	case prsd.Parsed:
+		rd := p.addMatch(v, gnp, prsd)
+		rd.MatchType = vlib.Exact
+		return rd, true
+	}
+	return vlib.ResultData{}, false
+}
@@ -12,10 +12,15 @@ func spQuery(
 	inp search.Input,
 	spWordIDs []int,
 	spWord string,
+	fw fuzzyWords,
 ) (string, []any) {
 	// prepare species epithet word for SQL
 	sp, insertStr := prepareSpWord(spWord)
 	args := []any{sp, spWordIDs}
+	if fw.spVariants != nil {
+		args[0] = fw.spVariants
+		insertStr = "modified = any($1::text[])"
+	}
 	q := fmt.Sprintf(`
 WITH sp AS (
   SELECT DISTINCT v.name_string_id
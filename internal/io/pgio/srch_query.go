@@ -18,10 +18,11 @@ func setQuery(
 	inp search.Input,
 	spWordIDs []int,
 	spWords string,
+	fw fuzzyWords,
 ) (string, []interface{}) {
-	spQ, args := spQuery(inp, spWordIDs, spWords)
+	spQ, args := spQuery(inp, spWordIDs, spWords, fw)
 	if inp.Author != "" {
-		spQ, args = auQuery(spQ, inp, args)
+		spQ, args = auQuery(spQ, inp, args, fw)
 	} else {
 		spQ, args = noAuQuery(spQ, inp, args)
 	}
@@ -33,12 +34,13 @@ func (p *pgio) runQuery(
 	ctx context.Context,
 	q string,
 	args []interface{},
+	fw fuzzyWords,
 ) (map[string]*verif.MatchRecord, error) {
 	searches, err := p.searchQuery(ctx, q, args)
 	if err != nil {
 		return nil, fmt.Errorf("pgio.runQuery: %w", err)
 	}
-	return p.matchRecords(searches), nil
+	return p.matchRecords(searches, fw), nil
 }
 
 func (p *pgio) searchQuery(
@@ -57,16 +59,18 @@ func (p *pgio) searchQuery(
 
 func (p *pgio) matchRecords(
 	searches []*verifSQL,
+	fw fuzzyWords,
 ) map[string]*verif.MatchRecord {
 	pCfg := gnparser.NewConfig(gnparser.OptWithDetails(true))
 	gnp := gnparser.New(pCfg)
-	res := p.organizeByCanonicals(gnp, searches)
+	res := p.organizeByCanonicals(gnp, searches, fw)
 	return res
 }
 
 func (p *pgio) organizeByCanonicals(
 	gnp gnparser.GNparser,
 	searches []*verifSQL,
+	fw fuzzyWords,
 ) map[string]*verif.MatchRecord {
 	res := make(map[string]*verif.MatchRecord)
 	for _, v := range searches {
@@ -82,7 +86,7 @@ func (p *pgio) organizeByCanonicals(
 		}
 		if m, ok := res[prsd.Canonical.Full]; ok {
 			mr := m.MatchResults
-			mr = append(mr, p.matchRes(gnp, prsd, v))
+			mr = append(mr, p.matchRes(gnp, prsd, v, fw))
 			res[prsd.Canonical.Full].MatchResults = mr
 		} else {
 			mr := verif.MatchRecord{
@@ -93,7 +97,7 @@ func (p *pgio) organizeByCanonicals(
 				CanonicalFull:   prsd.Canonical.Full,
 			}
 			mr.MatchResults = []*vlib.ResultData{
-				p.matchRes(gnp, prsd, v),
+				p.matchRes(gnp, prsd, v, fw),
 			}
 			res[prsd.Canonical.Full] = &mr
 		}
@@ -106,6 +110,7 @@ func (p *pgio) matchRes(
 	gnp gnparser.GNparser,
 	prsd parsed.Parsed,
 	v *verifSQL,
+	fw fuzzyWords,
 ) *vlib.ResultData {
 	authors, year := processAuthorship(prsd.Authorship)
 	hasTaxonData := p.hasTaxonData(v)
@@ -129,26 +134,30 @@ func (p *pgio) matchRes(
 	currentCardinality := int(prsdCurrent.Cardinality)
 
 	dsID := v.DataSourceID
-	titleShort := p.dsm[dsID].TitleShort
+	ds, ok := p.reg.Get(dsID)
+	if !ok {
+		ds = &vlib.DataSource{}
+	}
+	titleShort := ds.TitleShort
 	if titleShort == "" {
-		titleShort = p.dsm[dsID].Title
+		titleShort = ds.Title
 	}
 
 	var outlink string
-	if p.dsm[dsID].OutlinkURL != "" && v.OutlinkID.String != "" {
+	if ds.OutlinkURL != "" && v.OutlinkID.String != "" {
 		outlink = strings.Replace(
-			p.dsm[dsID].OutlinkURL,
+			ds.OutlinkURL,
 			"{}", v.OutlinkID.String, 1)
 	}
 
 	rd := vlib.ResultData{
 		DataSourceID:           dsID,
 		DataSourceTitleShort:   titleShort,
-		Curation:               p.dsm[dsID].Curation,
+		Curation:               ds.Curation,
 		RecordID:               v.RecordID.String,
 		LocalID:                v.LocalID.String,
 		Outlink:                outlink,
-		EntryDate:              p.dsm[dsID].UpdatedAt,
+		EntryDate:              ds.UpdatedAt,
 		ParsingQuality:         prsd.ParseQuality,
 		MatchedName:            v.Name.String,
 		MatchedCardinality:     matchedCardinality,
@@ -164,6 +173,10 @@ func (p *pgio) matchRes(
 		ClassificationRanks:    v.ClassificationRanks.String,
 		ClassificationIDs:      v.ClassificationIds.String,
 		MatchType:              vlib.FacetedSearch,
+		EditDistance:           fw.nameEditDistance(prsd),
+	}
+	if rd.EditDistance > 0 {
+		rd.MatchType = vlib.Fuzzy
 	}
 	rd.IsSynonym = rd.TaxonomicStatus == vlib.SynonymTaxStatus
 	return &rd
@@ -3,6 +3,7 @@ package pgio
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnlib/ent/verifier"
@@ -13,6 +14,7 @@ func (p *pgio) GetVernaculars(
 	ctx context.Context,
 	records []vern.Record,
 	langs []string,
+	countries []string,
 ) (map[vern.Record][]verifier.Vernacular, error) {
 
 	tx, err := p.db.Begin(ctx)
@@ -27,7 +29,7 @@ func (p *pgio) GetVernaculars(
 		return nil, fmt.Errorf("pgio.GetVernaculars: %w", err)
 	}
 
-	res, err = p.getVernaculars(ctx, tx, langs)
+	res, err = p.getVernaculars(ctx, tx, langs, countries)
 	if err != nil {
 		return nil, fmt.Errorf("pgio.GetVernaculars: %w", err)
 	}
@@ -76,21 +78,31 @@ func (p *pgio) getVernaculars(
 	ctx context.Context,
 	tx pgx.Tx,
 	langs []string,
+	countries []string,
 ) (map[vern.Record][]verifier.Vernacular, error) {
 	res := make(map[vern.Record][]verifier.Vernacular)
 	if len(langs) == 0 {
 		return res, nil
 	}
+	// language_orig is used when the normalized language is unknown.
 	q := `
-SELECT vs.name, vr.data_source_id, vr.record_id, vr.current_record_id, vsi.language, vsi.lang_code, vsi.country_code
+SELECT vs.name, vr.data_source_id, vr.record_id, vr.current_record_id,
+    COALESCE(NULLIF(vsi.language, ''), vsi.language_orig, ''),
+    COALESCE(vsi.lang_code, ''), COALESCE(vsi.locality, ''),
+    COALESCE(vsi.country_code, '')
 	FROM vernacular_string_indices vsi
 	  JOIN temp_vern_records vr ON vr.data_source_id = vsi.data_source_id AND vr.current_record_id = vsi.record_id
 	  JOIN vernacular_strings vs ON vs.id = vsi.vernacular_string_id
-	WHERE $1 = 'all' OR vsi.lang_code = ANY($2::text[])
+	WHERE ($1 = 'all' OR vsi.lang_code = ANY($2::text[]))
+	  AND (cardinality($3::text[]) = 0 OR upper(vsi.country_code) = ANY($3::text[]))
   ORDER BY vsi.lang_code asc, LENGTH(vs.name) - LENGTH(REPLACE(vs.name, ' ', '')) desc, vs.name asc
 	`
 	allLangs := langs[0]
-	rows, err := tx.Query(ctx, q, allLangs, langs)
+	cs := make([]string, len(countries))
+	for i := range countries {
+		cs[i] = strings.ToUpper(countries[i])
+	}
+	rows, err := tx.Query(ctx, q, allLangs, langs, cs)
 	if err != nil {
 		return res, fmt.Errorf("pgio.getVernaculars: finding vernaculars failed: %w", err)
 	}
@@ -106,6 +118,7 @@ SELECT vs.name, vr.data_source_id, vr.record_id, vr.current_record_id, vsi.langu
 			&k.CurrentRecordID,
 			&vrn.Language,
 			&vrn.LanguageCode,
+			&vrn.Locality,
 			&vrn.Country,
 		)
 		if err != nil {
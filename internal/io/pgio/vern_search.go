@@ -0,0 +1,165 @@
+package pgio
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gnames/gnames/pkg/ent/vern"
+)
+
+// vernSearchLimit is the maximal number of results returned by a search
+// for vernacular names. Short prefix queries could match too many names
+// otherwise.
+const vernSearchLimit = 1000
+
+// vernNormFunc normalizes vernacular names the same way as vern.Normalize
+// does. The gn_vern_norm function and its index are created by
+// migrations/vernacular_norm.sql.
+const vernNormFunc = `gn_vern_norm(vs.name)`
+
+// vernNormExpr returns an SQL expression that normalizes vernacular names.
+// If the database has no gn_vern_norm function, the same normalization is
+// done inline. The inline expression works without the migration, but
+// cannot use the index of normalized names.
+func vernNormExpr(hasFunc bool) string {
+	if hasFunc {
+		return vernNormFunc
+	}
+	from, to := vern.TranslateChars()
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return fmt.Sprintf(
+		`trim(regexp_replace(translate(lower(vs.name), %s, %s), '\s+', ' ', 'g'))`,
+		quote(from), quote(to),
+	)
+}
+
+// hasVernNorm checks if the gn_vern_norm function exists in the database.
+func (p *pgio) hasVernNorm(ctx context.Context) (bool, error) {
+	var res bool
+	q := `SELECT to_regprocedure('gn_vern_norm(text)') IS NOT NULL`
+	if err := p.db.QueryRow(ctx, q).Scan(&res); err != nil {
+		return false, fmt.Errorf("pgio.hasVernNorm: %w", err)
+	}
+	return res, nil
+}
+
+// SearchVernaculars finds vernacular names that match the query, and
+// returns them together with scientific names of corresponding taxa.
+func (p *pgio) SearchVernaculars(
+	ctx context.Context,
+	inp vern.SearchInput,
+) ([]vern.SearchResult, error) {
+	query := vern.Normalize(inp.Query)
+	if query == "" {
+		return nil, nil
+	}
+
+	args := []any{query}
+	cond := fmt.Sprintf("%s = $1", p.vernNorm)
+	if inp.WithPrefix {
+		args = append(args, escapeLike(query)+"%")
+		cond = fmt.Sprintf("%s LIKE $2", p.vernNorm)
+	}
+	if len(inp.Languages) > 0 {
+		args = append(args, inp.Languages)
+		cond += fmt.Sprintf("\n    AND vsi.lang_code = ANY($%d::text[])", len(args))
+	}
+	if len(inp.DataSources) > 0 {
+		args = append(args, inp.DataSources)
+		cond += fmt.Sprintf("\n    AND v.data_source_id = ANY($%d::int[])", len(args))
+	}
+
+	// The order is the same as in sortVernSearch, so the best results
+	// are not cut off by the limit.
+	q := fmt.Sprintf(`
+SELECT vs.name, %[1]s, vsi.language, vsi.lang_code, vsi.locality,
+    vsi.country_code, v.data_source_id, v.record_id, v.name_string_id,
+    v.name, v.outlink_id, v.classification, v.classification_ranks
+  FROM vernacular_strings vs
+    JOIN vernacular_string_indices vsi
+      ON vsi.vernacular_string_id = vs.id
+    JOIN verification v
+      ON v.data_source_id = vsi.data_source_id
+        AND v.record_id = vsi.record_id
+    JOIN data_sources ds
+      ON ds.id = v.data_source_id
+  WHERE %[2]s
+  ORDER BY %[1]s = $1 DESC, v.data_source_id = 1 DESC,
+    ds.is_curated DESC, ds.is_auto_curated DESC,
+    v.data_source_id, vs.name, v.name
+  LIMIT %[3]d
+`, p.vernNorm, cond, vernSearchLimit)
+
+	rows, err := p.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pgio.SearchVernaculars: %w", err)
+	}
+	defer rows.Close()
+
+	var res []vern.SearchResult
+	for rows.Next() {
+		var normName string
+		var lang, langCode, locality, country, outlinkID sql.NullString
+		var cl, clRanks sql.NullString
+		var r vern.SearchResult
+		err = rows.Scan(
+			&r.Vernacular.Name, &normName, &lang, &langCode, &locality,
+			&country, &r.DataSourceID, &r.RecordID, &r.NameID,
+			&r.Name, &outlinkID, &cl, &clRanks,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgio.SearchVernaculars: %w", err)
+		}
+		r.Vernacular.Language = lang.String
+		r.Vernacular.LanguageCode = langCode.String
+		r.Vernacular.Locality = locality.String
+		r.Vernacular.Country = country.String
+		r.ClassificationPath = cl.String
+		r.ClassificationRanks = clRanks.String
+		r.IsPrefixMatch = normName != query
+
+		vsql := &verifSQL{DataSourceID: r.DataSourceID, OutlinkID: outlinkID}
+		ds, outlink, title := p.dsData(vsql)
+		r.DataSourceTitleShort = title
+		r.Curation = ds.Curation
+		r.Outlink = outlink
+		res = append(res, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("pgio.SearchVernaculars: %w", err)
+	}
+
+	sortVernSearch(res)
+	return res, nil
+}
+
+// sortVernSearch puts full matches before prefix matches, and results from
+// curated data-sources before results from uncurated ones.
+func sortVernSearch(res []vern.SearchResult) {
+	slices.SortStableFunc(res, func(a, b vern.SearchResult) int {
+		if a.IsPrefixMatch != b.IsPrefixMatch {
+			if a.IsPrefixMatch {
+				return 1
+			}
+			return -1
+		}
+		rankA := vern.CurationRank(a.DataSourceID, a.Curation)
+		rankB := vern.CurationRank(b.DataSourceID, b.Curation)
+		if c := cmp.Compare(rankB, rankA); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.DataSourceID, b.DataSourceID); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Vernacular.Name, b.Vernacular.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
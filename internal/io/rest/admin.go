@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// addAdmin registers the admin API. Requests to the admin API must have
+// `Authorization: Bearer <token>` header. The admin API is not registered
+// if the token is empty. It returns the registered routes.
+func addAdmin(e *echo.Echo, gn gnames.GNames, token string) []route {
+	if token == "" {
+		slog.Info("Admin API is disabled, AdminToken is not set")
+		return nil
+	}
+	g := e.Group(apiPath+"admin", middleware.KeyAuth(
+		func(key string, _ echo.Context) (bool, error) {
+			ok := subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1
+			return ok, nil
+		},
+	))
+	res := adminRoutes(gn)
+	addRoutes(g.Add, res)
+	return res
+}
+
+// adminStatusGET returns the runtime state of the service.
+func adminStatusGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		res, err := gn.AdminStatus()
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// adminConfigGET returns the effective configuration without secrets.
+func adminConfigGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, gn.GetConfig().Redacted())
+	}
+}
+
+// rebuildMatcherPOST recreates caches of the embedded matcher. It can take
+// a long time, matching uses old caches until the rebuild is finished.
+func rebuildMatcherPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		err := gn.RebuildMatcherCache()
+		if errors.Is(err, gnames.ErrRemoteMatcher) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		res, err := gn.AdminStatus()
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, res.MatcherCaches)
+	}
+}
+
+// flushDataSourceStatsPOST removes cached statistics of data-sources and
+// returns their number.
+func flushDataSourceStatsPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		res := map[string]int{"flushedNum": gn.FlushDataSourceStats()}
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// reloadDataSourcesPOST reloads metadata of data-sources from the database
+// and returns IDs of added, removed and updated data-sources.
+func reloadDataSourcesPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		res, err := gn.ReloadDataSources(ctx)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// refreshDataSourceStatsPOST recomputes cached statistics of a
+// data-source. Public endpoints only return cached statistics, because
+// the computation scans all records of the data-source.
+func refreshDataSourceStatsPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		id, _ := url.PathUnescape(c.Param("id"))
+		ds, err := gn.DataSource(id)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		res, err := gn.DataSourceStats(ctx, ds.ID, true)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// reloadDataSourcesEvery reloads metadata of data-sources periodically
+// until the context is cancelled.
+func reloadDataSourcesEvery(
+	ctx context.Context,
+	gn gnames.GNames,
+	interval time.Duration,
+) {
+	slog.Info("Data-sources metadata will be reloaded periodically",
+		slog.Duration("interval", interval),
+	)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := gn.ReloadDataSources(ctx); err != nil {
+				slog.Error("Cannot reload data-sources", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/labstack/echo/v4"
+)
+
+// apiKeyCtx is the name of the API key of a client in echo context.
+const apiKeyCtx = "apiKey"
+
+// clients keeps API keys, and IDs of data-sources visible to anonymous
+// clients.
+type clients struct {
+	keys   map[string]config.APIKey
+	public []int
+}
+
+// newClients collects API keys from the configuration and from the API
+// keys file.
+func newClients(cfg config.Config) (*clients, error) {
+	keys := slices.Clone(cfg.APIKeys)
+	if cfg.APIKeysFile != "" {
+		fileKeys, err := config.LoadAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("rest.newClients: %w", err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+
+	res := clients{
+		keys:   make(map[string]config.APIKey, len(keys)),
+		public: cfg.PublicDataSources,
+	}
+	for _, k := range keys {
+		if _, ok := res.keys[k.Key]; ok {
+			return nil, fmt.Errorf("rest.newClients: duplicate key of %q", k.Name)
+		}
+		res.keys[k.Key] = k
+	}
+	return &res, nil
+}
+
+// middleware identifies clients by API keys and adds data-sources visible
+// to them to the context of the request. Requests with unknown keys are
+// rejected.
+func (cl *clients) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		acc := access.Access{DataSources: cl.public}
+		key := c.Request().Header.Get(apiKeyHeader)
+		if key != "" && len(cl.keys) > 0 {
+			k, ok := cl.find(key)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "unknown API key")
+			}
+			acc = access.Access{Client: k.Name, DataSources: k.DataSources}
+			c.Set(apiKeyCtx, k)
+		}
+		req := c.Request()
+		c.SetRequest(req.WithContext(access.NewContext(req.Context(), acc)))
+		return next(c)
+	}
+}
+
+// find returns the API key that matches the given one. Keys are compared
+// in constant time, and all of them are checked, so the duration of the
+// check does not reveal the keys.
+func (cl *clients) find(key string) (config.APIKey, bool) {
+	var res config.APIKey
+	var ok bool
+	for k, v := range cl.keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+			res, ok = v, true
+		}
+	}
+	return res, ok
+}
@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/labstack/echo/v4"
+)
+
+// canonicalGET lists name-strings that share a canonical form. The
+// canonical form is given by its UUID or by a name.
+func canonicalGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		id, _ := url.PathUnescape(c.Param("id"))
+		kind, err := canonical.NewKind(c.QueryParam("type"))
+		if err != nil {
+			return newParamError("type", "must be simple, full or stem")
+		}
+		inp := canonical.Input{ID: id, Kind: kind}
+		if inp.DataSources, err = intsParam(c, "data_sources"); err != nil {
+			return err
+		}
+		if inp.Limit, err = intParam(c, "limit"); err != nil {
+			return err
+		}
+		if inp.Offset, err = intParam(c, "offset"); err != nil {
+			return err
+		}
+		if inp.Limit < 0 {
+			return newParamError("limit", "must not be negative")
+		}
+		if inp.Offset < 0 {
+			return newParamError("offset", "must not be negative")
+		}
+
+		ctx, cancel := getContext(c)
+		defer cancel()
+		res, err := gn.Canonical(ctx, inp)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return newParamError("data_sources", "%s", err.Error())
+		}
+		if errors.Is(err, canonical.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.canonicalGET: %w", err)
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
@@ -0,0 +1,46 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalGET(t *testing.T) {
+	assert := assert.New(t)
+	var res canonical.Output
+	resp := makeGetRequest(t, "canonicals/Bubo%20bubo")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	assert.Equal("Bubo bubo", res.Canonical)
+	require.NotEmpty(t, res.Groups)
+	assert.Equal(len(res.Groups), res.GroupsNum)
+	var names []string
+	for _, g := range res.Groups {
+		for _, ns := range g.NameStrings {
+			names = append(names, ns.Name)
+		}
+	}
+	assert.Contains(names, "Bubo bubo (Linnaeus, 1758)")
+
+	resp = makeGetRequest(t, "canonicals/Bubo%20bubo?type=stem&data_sources=1")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	resp = makeGetRequest(t, "canonicals/Bubo%20bubo?limit=1")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	assert.Equal(1, res.RecordsNum)
+	assert.Equal(1, res.NextOffset)
+
+	resp = makeGetRequest(t, "canonicals/Bubo%20bubo?type=bad")
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeGetRequest(t, "canonicals/Bubo%20bubo?data_sources=col")
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeGetRequest(t, "canonicals/Nothing%20here")
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
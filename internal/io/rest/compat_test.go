@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestV1Routes pins routes of the v1 API. The v1 API is kept for
+// compatibility, its routes must not disappear.
+func TestV1Routes(t *testing.T) {
+	assert := assert.New(t)
+	e, err := newServer(nil, config.New())
+	assert.Nil(err)
+
+	var res []string
+	for _, r := range e.Routes() {
+		if r.Method == echo.RouteNotFound || isV2(r.Path) {
+			continue
+		}
+		res = append(res, r.Method+" "+r.Path)
+	}
+	slices.Sort(res)
+	assert.Equal([]string{
+		"GET /",
+		"GET /api",
+		"GET /api/",
+		"GET /api/v1",
+		"GET /api/v1/",
+		"GET /api/v1/canonicals/:id",
+		"GET /api/v1/conflicts/:name",
+		"GET /api/v1/data_sources",
+		"GET /api/v1/data_sources/:id",
+		"GET /api/v1/data_sources/:id/stats",
+		"GET /api/v1/docs",
+		"GET /api/v1/name_strings",
+		"GET /api/v1/name_strings/",
+		"GET /api/v1/name_strings/:id",
+		"GET /api/v1/openapi.json",
+		"GET /api/v1/ping",
+		"GET /api/v1/reconcile",
+		"GET /api/v1/reconcile/properties",
+		"GET /api/v1/records/:dataSourceID/:recordID",
+		"GET /api/v1/search/:query",
+		"GET /api/v1/verifications/:names",
+		"GET /api/v1/verify/:names",
+		"GET /api/v1/vernaculars/search/:query",
+		"GET /api/v1/version",
+		"GET /healthz",
+		"GET /readyz",
+		"POST /api/v1/name_strings",
+		"POST /api/v1/reconcile",
+		"POST /api/v1/records",
+		"POST /api/v1/search",
+		"POST /api/v1/verifications",
+		"POST /api/v1/verify",
+	}, res)
+}
+
+func TestErrorFormats(t *testing.T) {
+	assert := assert.New(t)
+	e, err := newServer(nil, config.New())
+	assert.Nil(err)
+
+	// v1 errors keep echo format
+	rec := serve(e, http.MethodGet, apiPath+"nothing", "")
+	assert.Equal(http.StatusNotFound, rec.Code)
+	assert.JSONEq(`{"message":"Not Found"}`, rec.Body.String())
+
+	rec = serve(e, http.MethodGet, apiPathV2+"nothing", "")
+	assert.Equal(http.StatusNotFound, rec.Code)
+	var res envelope.Response[any]
+	assert.Nil(json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal("v2", res.Meta.APIVersion)
+	assert.Nil(res.Data)
+	assert.Equal([]envelope.Error{
+		{Status: 404, Code: "not_found", Message: "Not Found"},
+	}, res.Errors)
+
+	rec = serve(e, http.MethodGet, apiPathV2+"verify?names=Bubo&stats=yes", "")
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	res = envelope.Response[any]{}
+	assert.Nil(json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal("invalid_parameter", res.Errors[0].Code)
+	assert.Equal("stats", res.Errors[0].Parameter)
+}
+
+func TestNegotiation(t *testing.T) {
+	assert := assert.New(t)
+	e, err := newServer(nil, config.New())
+	assert.Nil(err)
+
+	tests := []struct {
+		accept string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"*/*", http.StatusOK},
+		{"application/*", http.StatusOK},
+		{"application/json", http.StatusOK},
+		{"text/html, application/json;q=0.5", http.StatusOK},
+		{"text/html,application/xml;q=0.9,*/*;q=0.8", http.StatusOK},
+		{"text/plain", http.StatusNotAcceptable},
+		{"application/json;q=0", http.StatusNotAcceptable},
+	}
+	for _, v := range tests {
+		rec := serve(e, http.MethodGet, apiPathV2, v.accept)
+		assert.Equal(v.status, rec.Code, v.accept)
+		assert.True(strings.HasPrefix(
+			rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON,
+		), v.accept)
+	}
+
+	typ, ok := negotiate("text/csv;q=0.4, text/*;q=0.9", []string{
+		echo.MIMEApplicationJSON, "text/csv", "text/tab-separated-values",
+	})
+	assert.True(ok)
+	assert.Equal("text/csv", typ)
+}
+
+func TestFormatParam(t *testing.T) {
+	assert := assert.New(t)
+	e, err := newServer(nil, config.New())
+	assert.Nil(err)
+
+	rec := serve(e, http.MethodGet, apiPathV2+"verify?names=Bubo&format=yaml", "")
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	var res envelope.Response[any]
+	assert.Nil(json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal("format", res.Errors[0].Parameter)
+
+	rec = serve(e, http.MethodGet, apiPathV2+"?format=csv", "")
+	assert.Equal(http.StatusBadRequest, rec.Code)
+
+	// Darwin Core Archive is only available for verification
+	rec = serve(e, http.MethodGet, apiPathV2+"search?q=n:Bubo&format=dwca", "")
+	assert.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = serve(e, http.MethodGet, apiPath+"verify/Bubo?format=yaml", "")
+	assert.Equal(http.StatusBadRequest, rec.Code)
+	assert.JSONEq(`{"message":"format: unsupported format \"yaml\""}`,
+		rec.Body.String())
+}
+
+func TestVerifFlags(t *testing.T) {
+	assert := assert.New(t)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet,
+		"/?names=Bubo&stats=true&conflicts=1&suggestions=false", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	inp, err := v2VerifyInput(c)
+	assert.Nil(err)
+	assert.True(inp.WithStats)
+	assert.True(inp.WithConflicts)
+	assert.False(inp.WithSuggestions)
+	assert.False(inp.WithAllMatches)
+
+	req = httptest.NewRequest(http.MethodGet, "/?names=Bubo&stats=yes", nil)
+	c = e.NewContext(req, httptest.NewRecorder())
+	_, err = v2VerifyInput(c)
+	var perr *paramError
+	assert.ErrorAs(err, &perr)
+	assert.Equal("stats", perr.param)
+}
+
+func TestRespond(t *testing.T) {
+	assert := assert.New(t)
+	e := echo.New()
+	names := []vlib.Name{{
+		ID:   "id1",
+		Name: "Bubo bubo",
+		BestResult: &vlib.ResultData{
+			DataSourceID: 1,
+			MatchedName:  "Bubo bubo (Linnaeus, 1758)",
+		},
+	}}
+	tests := []struct {
+		typ, contentType, body string
+	}{
+		{"", echo.MIMEApplicationJSON, `{"names":`},
+		{mimeCSV, mimeCSV, "Kind,Index,ID,Name,"},
+		{mimeTSV, mimeTSV, "Kind\tIndex\tID\tName\t"},
+		{mimeXML, mimeXML, "<dwc:scientificName>Bubo bubo (Linnaeus, 1758)"},
+	}
+	for _, v := range tests {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		if v.typ != "" {
+			c.Set(formatCtx, v.typ)
+		}
+		err := respond(c, map[string]any{"names": names}, names, false)
+		assert.Nil(err)
+		assert.True(strings.HasPrefix(
+			rec.Header().Get(echo.HeaderContentType), v.contentType,
+		), v.typ)
+		assert.Contains(rec.Body.String(), v.body, v.typ)
+	}
+}
+
+func TestRespondDwCA(t *testing.T) {
+	assert := assert.New(t)
+	e := echo.New()
+	out := verif.Output{Names: []verif.Name{
+		{Name: vlib.Name{ID: "id1", Name: "Bubo bubo", MatchType: vlib.NoMatch}},
+	}}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.Set(formatCtx, mimeDwCA)
+	assert.Nil(respondVerif(c, dsGNames{}, out, out, false))
+	assert.Equal(mimeDwCA, rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(rec.Header().Get(echo.HeaderContentDisposition),
+		"gnames-dwca.zip")
+	assert.True(strings.HasPrefix(rec.Body.String(), "PK"))
+}
+
+// dsGNames provides data-sources for Darwin Core Archive metadata.
+type dsGNames struct {
+	gnames.GNames
+}
+
+func (dsGNames) DataSources(...int) []*vlib.DataSource { return nil }
+
+func serve(e *echo.Echo, method, path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
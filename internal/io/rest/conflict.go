@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/labstack/echo/v4"
+)
+
+// conflictsGET verifies a name and reports disagreements of curated
+// data-sources about it.
+func conflictsGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		name, _ := url.PathUnescape(c.Param("name"))
+		if strings.TrimSpace(name) == "" {
+			return newParamError("name", "name-string is required")
+		}
+		var ds []int
+		for v := range strings.SplitSeq(c.QueryParam("data_sources"), ",") {
+			if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				ds = append(ds, i)
+			}
+		}
+
+		ctx, cancel := getContext(c)
+		defer cancel()
+		consensus, err := boolParam(c, "consensus")
+		if err != nil {
+			return err
+		}
+		inp := conflict.Input{
+			Name:          name,
+			DataSources:   ds,
+			WithConsensus: consensus,
+		}
+		res, err := gn.Conflicts(ctx, inp)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.conflictsGET: %w", err)
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/labstack/echo/v4"
+)
+
+// httpErrorHandler renders errors of the v2 API as envelopes. Errors of
+// the v1 API are left to the default echo handler, so their format does
+// not change.
+func httpErrorHandler(e *echo.Echo) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if !isV2(c.Request().URL.Path) {
+			var pe *paramError
+			if errors.As(err, &pe) {
+				err = echo.NewHTTPError(http.StatusBadRequest, pe.Error())
+			}
+			e.DefaultHTTPErrorHandler(err, c)
+			return
+		}
+		if c.Response().Committed {
+			return
+		}
+
+		res := v2Error(err)
+		if res.Status >= http.StatusInternalServerError {
+			slog.Error("Request failed",
+				slog.String("path", c.Request().URL.Path),
+				slog.String("error", err.Error()),
+			)
+		}
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(res.Status)
+		} else {
+			err = c.JSON(res.Status, envelope.NewError(res))
+		}
+		if err != nil {
+			slog.Error("Cannot send error response", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func isV2(path string) bool {
+	return strings.HasPrefix(path+"/", apiPathV2)
+}
+
+// v2Error converts an error to its description in the v2 API. Messages of
+// unexpected errors are hidden from clients.
+func v2Error(err error) envelope.Error {
+	var pe *paramError
+	if errors.As(err, &pe) {
+		return envelope.Error{
+			Status:    http.StatusBadRequest,
+			Code:      "invalid_parameter",
+			Message:   pe.msg,
+			Parameter: pe.param,
+		}
+	}
+
+	status := http.StatusInternalServerError
+	msg := http.StatusText(status)
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		status = he.Code
+		msg = fmt.Sprint(he.Message)
+	}
+	return envelope.Error{Status: status, Code: errorCode(status), Message: msg}
+}
+
+// errorCode creates a machine-readable code from a status, for example
+// `too_many_requests`.
+func errorCode(status int) string {
+	res := strings.ToLower(http.StatusText(status))
+	return strings.ReplaceAll(res, " ", "_")
+}
@@ -0,0 +1,52 @@
+package rest_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVerifyFormats checks CSV, TSV and XML outputs of verification.
+func TestVerifyFormats(t *testing.T) {
+	assert := assert.New(t)
+	resp := makeGetRequest(t,
+		"verify/Bubo+bubo|Pomatomus+saltatrix?format=csv&data_sources=1|11&all_matches=true")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.True(strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv"))
+	rows, err := csv.NewReader(strings.NewReader(
+		string(readResponseBody(t, resp)),
+	)).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(rows), 2)
+	assert.Equal("Kind", rows[0][0])
+	assert.Equal("Match", rows[1][0])
+	assert.Equal("Bubo bubo", rows[1][3])
+
+	req, err := http.NewRequest(http.MethodGet, restURL+"verify/Bubo+bubo", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/tab-separated-values")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body := string(readResponseBody(t, resp))
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	assert.Len(lines, 2)
+	assert.True(strings.HasPrefix(lines[1], "BestMatch\t0\t"))
+
+	resp = makeGetRequest(t, "name_strings/Bubo+bubo?format=xml")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Contains(string(readResponseBody(t, resp)), "<dwc:scientificName>")
+
+	// browsers get JSON
+	req, err = http.NewRequest(http.MethodGet, restURL+"verify/Bubo+bubo", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/html,application/xml;q=0.9,*/*;q=0.8")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.True(strings.HasPrefix(
+		resp.Header.Get("Content-Type"), "application/json",
+	))
+}
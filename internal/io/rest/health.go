@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/labstack/echo/v4"
+)
+
+// readyTimeout limits the duration of readiness checks.
+const readyTimeout = 5 * time.Second
+
+// healthz reports that the process is alive. It does not check
+// dependencies.
+func healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyz checks dependencies of the service. It returns 503 status if
+// any of them is not ready, for example while the embedded matcher loads
+// its caches.
+func readyz(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		ctx, cancel := context.WithTimeout(c.Request().Context(), readyTimeout)
+		defer cancel()
+
+		res := gn.Readiness(ctx)
+		status := http.StatusOK
+		if !res.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		return c.JSON(status, res)
+	}
+}
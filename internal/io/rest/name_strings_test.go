@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gnames/gnames/pkg/ent/verif"
 	"github.com/gnames/gnfmt"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/stretchr/testify/assert"
@@ -68,6 +69,43 @@ func TestNameStringsVirusID(t *testing.T) {
 	assert.NotNil(response.Name.BestResult)
 	assert.Equal(1, response.Name.BestResult.DataSourceID)
 }
+
+func TestNameStringsPOST(t *testing.T) {
+	assert := assert.New(t)
+	var response verif.NameStringsOutput
+	id := "0eeccd70-eaf2-5c51-ad8b-46cfb3db1645"
+	virusID := "e7966ea7-75aa-5ef2-abcc-8d66c94c5d78"
+	input := verif.NameStringsInput{
+		IDs: []string{
+			"Vubo bubo (Linnaeus, 1758",
+			id,
+			"Bubo bubo (Linnaeus, 1758)",
+			virusID,
+		},
+		DataSources: []int{1},
+	}
+	resp := makePostRequest(t, "name_strings", input)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &response)
+
+	assert.Equal(4, response.NamesNum)
+	assert.Equal(1, response.NotFoundNum)
+	assert.Len(response.Names, 4)
+	assert.True(response.Names[0].NotFound)
+	assert.Nil(response.Names[0].Name)
+	for i, v := range []string{id, id, virusID} {
+		ns := response.Names[i+1]
+		assert.Equal(input.IDs[i+1], ns.Input)
+		assert.Equal(v, ns.ID)
+		assert.False(ns.NotFound)
+		assert.NotNil(ns.Name.BestResult)
+		assert.Equal(1, ns.Name.BestResult.DataSourceID)
+	}
+
+	resp = makePostRequest(t, "name_strings", verif.NameStringsInput{})
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
 func TestNameStringsIDBad(t *testing.T) {
 	var response vlib.NameStringOutput
 	id := "1eeccd70-eaf2-5c51-ad8b-46cfb3db1645"
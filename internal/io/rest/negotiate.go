@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gnames/gnames/internal/io/dwcaio"
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/flat"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/labstack/echo/v4"
+)
+
+// formatCtx is the name of the negotiated media type in echo context.
+const formatCtx = "format"
+
+// Media types of responses besides JSON.
+const (
+	mimeCSV  = "text/csv"
+	mimeTSV  = "text/tab-separated-values"
+	mimeXML  = echo.MIMEApplicationXML
+	mimeDwCA = "application/zip"
+)
+
+// jsonOnly are media types of endpoints that return only JSON.
+var jsonOnly = []string{echo.MIMEApplicationJSON}
+
+// flatFormats are media types of endpoints that return verification
+// results. Results can be flattened to CSV, TSV and XML.
+var flatFormats = []string{echo.MIMEApplicationJSON, mimeCSV, mimeTSV, mimeXML}
+
+// verifyFormats are media types of verification endpoints. Besides flat
+// formats, verification results can be exported as a Darwin Core Archive.
+var verifyFormats = append(slices.Clone(flatFormats), mimeDwCA)
+
+// formatParams map values of the `format` parameter to media types.
+var formatParams = map[string]string{
+	"json": echo.MIMEApplicationJSON,
+	"csv":  mimeCSV,
+	"tsv":  mimeTSV,
+	"xml":  mimeXML,
+	"dwca": mimeDwCA,
+}
+
+// mediaRange is an entry of the Accept header.
+type mediaRange struct {
+	typ string
+	q   float64
+}
+
+// negotiation chooses the media type of a response from the supported
+// ones. The `format` parameter has the priority over the Accept header.
+// The chosen type is saved to the echo context, the first supported type
+// is the default.
+//
+// Browsers accept XML with a high preference, so requests that accept
+// HTML get the default type, unless they use the `format` parameter.
+// If strict is true, requests that do not accept any of the supported
+// types get 406 status, otherwise they get the default type.
+func negotiation(strict bool, supported ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if f := c.QueryParam("format"); f != "" {
+				typ, ok := formatParams[strings.ToLower(f)]
+				if !ok || !slices.Contains(supported, typ) {
+					return newParamError("format", "unsupported format %q", f)
+				}
+				c.Set(formatCtx, typ)
+				return next(c)
+			}
+
+			typ, ok := negotiate(c.Request().Header.Get(echo.HeaderAccept), supported)
+			if !ok && strict {
+				return echo.NewHTTPError(http.StatusNotAcceptable,
+					"supported media types: "+strings.Join(supported, ", "))
+			}
+			if !ok {
+				typ = supported[0]
+			}
+			c.Set(formatCtx, typ)
+			return next(c)
+		}
+	}
+}
+
+// negotiate returns the supported media type with the highest preference
+// in the Accept header.
+func negotiate(accept string, supported []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return supported[0], true
+	}
+	rs := parseAccept(accept)
+	if slices.ContainsFunc(rs, func(r mediaRange) bool {
+		return r.typ == echo.MIMETextHTML
+	}) {
+		return supported[0], true
+	}
+	for _, r := range rs {
+		for _, s := range supported {
+			if matchMedia(r.typ, s) {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseAccept returns media ranges of the Accept header sorted by their
+// preference. Ranges with zero preference are dropped.
+func parseAccept(accept string) []mediaRange {
+	var res []mediaRange
+	for v := range strings.SplitSeq(accept, ",") {
+		typ, params, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qs, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			res = append(res, mediaRange{typ: typ, q: q})
+		}
+	}
+	slices.SortStableFunc(res, func(a, b mediaRange) int {
+		return cmp.Compare(b.q, a.q)
+	})
+	return res
+}
+
+func matchMedia(rng, typ string) bool {
+	if rng == "*/*" || rng == typ {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(rng, "*")
+	return ok && strings.HasPrefix(typ, prefix)
+}
+
+// respond sends the response in the negotiated format. JSON gets the
+// whole response, other formats get flattened names. If allMatches is
+// true, flattened output has all results of names, otherwise only the
+// best ones.
+func respond(
+	c echo.Context,
+	res any,
+	names []vlib.Name,
+	allMatches bool,
+) error {
+	typ, _ := c.Get(formatCtx).(string)
+	switch typ {
+	case mimeCSV:
+		recs := flat.Records(names, allMatches)
+		return c.Blob(http.StatusOK, mimeCSV+"; charset=utf-8", flat.CSV(recs, ','))
+	case mimeTSV:
+		recs := flat.Records(names, allMatches)
+		return c.Blob(http.StatusOK, mimeTSV+"; charset=utf-8", flat.CSV(recs, '\t'))
+	case mimeXML:
+		bs, err := flat.XML(flat.Records(names, allMatches))
+		if err != nil {
+			return fmt.Errorf("rest.respond: %w", err)
+		}
+		return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, bs)
+	default:
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// respondVerif sends results of verification in the negotiated format.
+// Darwin Core Archive is sent as a zip attachment, other formats are
+// sent by respond.
+func respondVerif(
+	c echo.Context,
+	gn gnames.GNames,
+	res any,
+	out verif.Output,
+	allMatches bool,
+) error {
+	typ, _ := c.Get(formatCtx).(string)
+	if typ != mimeDwCA {
+		return respond(c, res, verifierNames(out.Names), allMatches)
+	}
+
+	var buf bytes.Buffer
+	if err := dwcaio.Write(&buf, out, gn.DataSources); err != nil {
+		return fmt.Errorf("rest.respondVerif: %w", err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		`attachment; filename="gnames-dwca.zip"`)
+	return c.Blob(http.StatusOK, mimeDwCA, buf.Bytes())
+}
+
+// verifierNames returns names of verification without gnames-specific
+// data.
+func verifierNames(names []verif.Name) []vlib.Name {
+	res := make([]vlib.Name, len(names))
+	for i := range names {
+		res[i] = names[i].Name
+	}
+	return res
+}
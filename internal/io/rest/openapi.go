@@ -0,0 +1,311 @@
+package rest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/labstack/echo/v4"
+)
+
+//go:embed docs.html
+var docsHTML string
+
+// apiDoc keeps the OpenAPI specification of the registered routes.
+type apiDoc struct {
+	spec []byte
+}
+
+// openAPIGET returns the OpenAPI specification.
+func (d *apiDoc) openAPIGET(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, d.spec)
+}
+
+// docsGET returns a page that renders the OpenAPI specification.
+func docsGET(c echo.Context) error {
+	return c.HTML(http.StatusOK, docsHTML)
+}
+
+type openAPI struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       openAPIInfo                     `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	OperationID string                `json:"operationId"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema schema `json:"schema"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type schema struct {
+	Ref                  string            `json:"$ref,omitempty"`
+	Type                 string            `json:"type,omitempty"`
+	Format               string            `json:"format,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	Items                *schema           `json:"items,omitempty"`
+	Properties           map[string]schema `json:"properties,omitempty"`
+	AdditionalProperties *schema           `json:"additionalProperties,omitempty"`
+	Required             []string          `json:"required,omitempty"`
+}
+
+type components struct {
+	Schemas         map[string]schema         `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// bodies describe request bodies of endpoints. The key is the name of
+// the schema.
+var bodies = map[string]struct {
+	contentType string
+	schema      schema
+}{
+	"VerificationInput": {"application/json", schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"nameStrings": {Type: "array", Items: &schema{Type: "string"}},
+			"dataSources": {Type: "array", Items: &schema{Type: "string"},
+				Description: "IDs, UUIDs or short titles of data-sources"},
+			"withAllMatches":          {Type: "boolean"},
+			"withCapitalization":      {Type: "boolean"},
+			"withSpeciesGroup":        {Type: "boolean"},
+			"withRelaxedFuzzyMatch":   {Type: "boolean"},
+			"withUninomialFuzzyMatch": {Type: "boolean"},
+			"withStats":               {Type: "boolean"},
+			"withLexicalGroups":       {Type: "boolean"},
+			"withConflicts":           {Type: "boolean"},
+			"withConsensus":           {Type: "boolean"},
+			"mainTaxonThreshold":      {Type: "number"},
+			"statsDataSource": {Type: "integer",
+				Description: "ID of a data-source for statistics"},
+			"withContextRanking": {Type: "boolean"},
+			"withSuggestions":    {Type: "boolean"},
+			"suggestionsNum": {Type: "integer",
+				Description: "maximum number of suggestions for a name"},
+			"sort": {Type: "string",
+				Description: "`consensus` orders names by support of their consensus"},
+			"vernaculars": {Type: "array", Items: &schema{Type: "string"},
+				Description: "languages of vernacular names"},
+			"vernacularCountries": {Type: "array", Items: &schema{Type: "string"},
+				Description: "country codes of vernacular names"},
+		},
+		Required: []string{"nameStrings"},
+	}},
+	"NameStringsInput": {"application/json", schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"ids": {Type: "array", Items: &schema{Type: "string"},
+				Description: "UUIDs or spellings of name-strings"},
+			"dataSources":       {Type: "array", Items: &schema{Type: "integer"}},
+			"withAllMatches":    {Type: "boolean"},
+			"withLexicalGroups": {Type: "boolean"},
+		},
+		Required: []string{"ids"},
+	}},
+	"RecordsInput": {"application/json", schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"dataSourceId": {Type: "integer"},
+			"ids":          {Type: "array", Items: &schema{Type: "string"}},
+			"idType": {Type: "string",
+				Description: "record_id (default), local_id or global_id"},
+			"vernaculars": {Type: "array", Items: &schema{Type: "string"},
+				Description: "languages of vernacular names"},
+		},
+		Required: []string{"dataSourceId", "ids"},
+	}},
+	"SearchInput": {"application/json", schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"query":          {Type: "string", Description: "faceted query"},
+			"dataSources":    {Type: "array", Items: &schema{Type: "integer"}},
+			"withAllMatches": {Type: "boolean"},
+			"maxEditDist":    {Type: "integer"},
+		},
+	}},
+	"ReconcileForm": {"application/x-www-form-urlencoded", schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"queries": {Type: "string", Description: "JSON with queries"},
+			"extend":  {Type: "string", Description: "JSON with an extension"},
+		},
+	}},
+}
+
+// pathParam finds echo path parameters.
+var pathParam = regexp.MustCompile(`:([A-Za-z_]+)`)
+
+// newOpenAPI generates the OpenAPI specification of public routes and,
+// if they are enabled, of admin routes.
+func newOpenAPI(public, admin []route) ([]byte, error) {
+	doc := openAPI{
+		OpenAPI: "3.0.3",
+		Info: openAPIInfo{
+			Title: "GNames API",
+			Description: "Verification, reconciliation and search of " +
+				"scientific names.",
+			Version: gnames.Version,
+		},
+		Paths: make(map[string]map[string]operation),
+		Components: components{
+			Schemas: make(map[string]schema),
+			SecuritySchemes: map[string]securityScheme{
+				"apiKey": {Type: "apiKey", In: "header", Name: apiKeyHeader},
+			},
+		},
+	}
+	doc.Components.Schemas["Error"] = schema{
+		Type: "object",
+		Properties: map[string]schema{
+			"message": {Type: "string"},
+		},
+	}
+	for _, r := range public {
+		op := doc.newOperation(r)
+		op.Security = []map[string][]string{{}, {"apiKey": {}}}
+		doc.addOperation(r.path, r.method, op)
+	}
+	if len(admin) > 0 {
+		doc.Components.SecuritySchemes["adminToken"] = securityScheme{
+			Type: "http", Scheme: "bearer",
+		}
+	}
+	for _, r := range admin {
+		op := doc.newOperation(r)
+		op.Security = []map[string][]string{{"adminToken": {}}}
+		doc.addOperation(apiPath+"admin"+r.path, r.method, op)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func (doc *openAPI) addOperation(path, method string, op operation) {
+	path = pathParam.ReplaceAllString(path, "{$1}")
+	if _, ok := doc.Paths[path]; !ok {
+		doc.Paths[path] = make(map[string]operation)
+	}
+	if op.RequestBody != nil {
+		for _, mt := range op.RequestBody.Content {
+			name := strings.TrimPrefix(mt.Schema.Ref, "#/components/schemas/")
+			doc.Components.Schemas[name] = bodies[name].schema
+		}
+	}
+	op.OperationID = operationID(method, path)
+	doc.Paths[path][strings.ToLower(method)] = op
+}
+
+// newOperation describes a route. Schemas of responses are generated from
+// types of their bodies.
+func (doc *openAPI) newOperation(r route) operation {
+	ok := response{Description: "Successful response"}
+	if r.resp != nil {
+		t := reflect.TypeOf(r.resp)
+		contentType := echo.MIMEApplicationJSON
+		if t.Kind() == reflect.String {
+			contentType = echo.MIMETextPlain
+		}
+		ok.Content = map[string]mediaType{contentType: {Schema: doc.schemaOf(t)}}
+	}
+	errSchema := schema{Ref: "#/components/schemas/Error"}
+	if isV2(r.path) {
+		errSchema = doc.schemaOf(reflect.TypeFor[envelope.Response[any]]())
+	}
+	res := operation{
+		Tags:       []string{r.tag},
+		Summary:    r.summary,
+		Deprecated: r.deprecated,
+		Responses: map[string]response{
+			"200": ok,
+			"default": {
+				Description: "Error",
+				Content: map[string]mediaType{
+					echo.MIMEApplicationJSON: {Schema: errSchema},
+				},
+			},
+		},
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(r.path, -1) {
+		res.Parameters = append(res.Parameters, parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   schema{Type: "string"},
+		})
+	}
+	for _, p := range r.query {
+		res.Parameters = append(res.Parameters, parameter{
+			Name:        p.name,
+			In:          "query",
+			Description: p.desc,
+			Schema:      schema{Type: p.typ},
+		})
+	}
+	if b, ok := bodies[r.body]; ok {
+		res.RequestBody = &requestBody{
+			Required: true,
+			Content: map[string]mediaType{
+				b.contentType: {
+					Schema: schema{Ref: "#/components/schemas/" + r.body},
+				},
+			},
+		}
+	}
+	return res
+}
+
+// operationID creates a unique ID of an operation from its method and
+// path, for example `get_api_v1_data_sources_id`. Paths with a trailing
+// slash get `index` suffix.
+func operationID(method, path string) string {
+	res := strings.ToLower(method) + path
+	res = strings.NewReplacer("/", "_", "{", "", "}", "", ".", "_").Replace(res)
+	if strings.HasSuffix(res, "_") {
+		res += "index"
+	}
+	return res
+}
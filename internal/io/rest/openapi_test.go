@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIRoutes(t *testing.T) {
+	assert := assert.New(t)
+	cfg := config.New(config.OptAdminToken("token"))
+	e, err := newServer(nil, cfg)
+	assert.Nil(err)
+
+	req := httptest.NewRequest(http.MethodGet, apiPath+"openapi.json", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(http.StatusOK, rec.Code)
+
+	var doc openAPI
+	err = json.Unmarshal(rec.Body.Bytes(), &doc)
+	assert.Nil(err)
+	assert.Equal("3.0.3", doc.OpenAPI)
+
+	var routesNum int
+	ids := make(map[string]struct{})
+	for _, r := range e.Routes() {
+		if r.Method == echo.RouteNotFound {
+			continue
+		}
+		routesNum++
+		path := pathParam.ReplaceAllString(r.Path, "{$1}")
+		op, ok := doc.Paths[path][strings.ToLower(r.Method)]
+		assert.True(ok, r.Method+" "+r.Path)
+		assert.NotEmpty(op.Summary, r.Method+" "+r.Path)
+		ids[op.OperationID] = struct{}{}
+	}
+	assert.Greater(routesNum, 30)
+	assert.Len(ids, routesNum, "unique operation IDs")
+
+	op := doc.Paths[apiPath+"admin/status"]["get"]
+	assert.Equal("adminToken", firstKey(op.Security[0]))
+	op = doc.Paths[apiPath+"data_sources/{id}"]["get"]
+	assert.Equal("id", op.Parameters[0].Name)
+	assert.Equal("path", op.Parameters[0].In)
+	op = doc.Paths[apiPath+"verify"]["post"]
+	assert.NotNil(op.RequestBody)
+	assert.Contains(doc.Components.Schemas, "VerificationInput")
+	ok := op.Responses["200"].Content[echo.MIMEApplicationJSON]
+	assert.Equal("#/components/schemas/verif.Output", ok.Schema.Ref)
+	out := doc.Components.Schemas["verif.Output"]
+	assert.Contains(out.Properties, "names")
+	assert.Contains(doc.Components.Schemas, "verifier.Name")
+	errResp := op.Responses["default"].Content[echo.MIMEApplicationJSON]
+	assert.Equal("#/components/schemas/Error", errResp.Schema.Ref)
+
+	op = doc.Paths[apiPath+"ping"]["get"]
+	assert.Contains(op.Responses["200"].Content, echo.MIMETextPlain)
+	op = doc.Paths["/api/v2/verify"]["post"]
+	errResp = op.Responses["default"].Content[echo.MIMEApplicationJSON]
+	assert.Contains(errResp.Schema.Properties, "errors")
+}
+
+func TestOpenAPINoAdmin(t *testing.T) {
+	assert := assert.New(t)
+	e, err := newServer(nil, config.New())
+	assert.Nil(err)
+
+	req := httptest.NewRequest(http.MethodGet, apiPath+"openapi.json", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.NotContains(rec.Body.String(), "/admin/")
+	assert.NotContains(rec.Body.String(), "adminToken")
+
+	req = httptest.NewRequest(http.MethodGet, apiPath+"docs", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Contains(rec.Body.String(), "openapi.json")
+	assert.NotContains(rec.Body.String(), "<script src")
+}
+
+func firstKey(m map[string][]string) string {
+	for k := range m {
+		return k
+	}
+	return ""
+}
@@ -0,0 +1,236 @@
+package rest
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// clientTTL is the time after which an inactive client is forgotten.
+const clientTTL = 10 * time.Minute
+
+// apiKeyHeader is a header with an API key of a client.
+const apiKeyHeader = "X-API-Key"
+
+// bytesPerName is a generous estimate of the size of one name-string in
+// a request body.
+const bytesPerName = 1024
+
+// limiter keeps a token bucket for every client of an endpoint. A client
+// gets perMin requests per minute, and can use all of them at once.
+// Clients with API keys can have their own limits.
+type limiter struct {
+	perMin    int
+	keyPerMin func(config.APIKey) int
+
+	mu        sync.Mutex
+	clients   map[string]*client
+	lastPurge time.Time
+}
+
+type client struct {
+	lim      *rate.Limiter
+	lastSeen time.Time
+}
+
+// newLimiter creates a limiter for perMin requests per minute. The
+// keyPerMin function returns a limit of a client with an API key, zero
+// means the default limit. It returns nil if there are no limits at all.
+func newLimiter(
+	perMin int,
+	keyPerMin func(config.APIKey) int,
+	keys []config.APIKey,
+) *limiter {
+	enabled := perMin > 0
+	for _, k := range keys {
+		enabled = enabled || keyPerMin(k) > 0
+	}
+	if !enabled {
+		return nil
+	}
+	return &limiter{
+		perMin:    perMin,
+		keyPerMin: keyPerMin,
+		clients:   make(map[string]*client),
+		lastPurge: time.Now(),
+	}
+}
+
+// get returns the token bucket of a client, removing buckets of clients
+// that were inactive for a long time.
+func (l *limiter) get(key string, perMin int, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPurge) > clientTTL {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) > clientTTL {
+				delete(l.clients, k)
+			}
+		}
+		l.lastPurge = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok {
+		lim := rate.NewLimiter(rate.Limit(float64(perMin)/60), perMin)
+		c = &client{lim: lim}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+	return c.lim
+}
+
+// clientKey identifies a client by a validated API key, or by the IP
+// address of the client. API keys are set to the context only if they are
+// known, so random keys cannot be used to avoid limits.
+func clientKey(c echo.Context) (string, *config.APIKey) {
+	if k, ok := c.Get(apiKeyCtx).(config.APIKey); ok {
+		return "key:" + k.Key, &k
+	}
+	return "ip:" + c.RealIP(), nil
+}
+
+// middleware rejects requests of clients that exceeded their limit with
+// 429 status and Retry-After header.
+func (l *limiter) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		perMin := l.perMin
+		key, k := clientKey(c)
+		if k != nil {
+			if n := l.keyPerMin(*k); n > 0 {
+				perMin = n
+			}
+		}
+		if perMin <= 0 {
+			return next(c)
+		}
+
+		now := time.Now()
+		lim := l.get(key, perMin, now)
+		res := lim.ReserveN(now, 1)
+		h := c.Response().Header()
+		h.Set("X-RateLimit-Limit", strconv.Itoa(perMin))
+
+		if delay := res.DelayFrom(now); delay > 0 {
+			res.CancelAt(now)
+			h.Set("X-RateLimit-Remaining", "0")
+			h.Set("Retry-After", retryAfter(delay))
+			return echo.NewHTTPError(
+				http.StatusTooManyRequests, "rate limit exceeded",
+			)
+		}
+		remain := max(int(lim.TokensAt(now)), 0)
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remain))
+		return next(c)
+	}
+}
+
+// concurrency limits the number of requests processed at the same time.
+// Extra requests are rejected with 503 status.
+func concurrency(num int) echo.MiddlewareFunc {
+	sem := make(chan struct{}, num)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				return next(c)
+			default:
+				c.Response().Header().Set("Retry-After", "1")
+				return echo.NewHTTPError(
+					http.StatusServiceUnavailable, "too many concurrent requests",
+				)
+			}
+		}
+	}
+}
+
+// limits returns middlewares that apply a rate limit, and optionally a
+// limit of concurrent requests, to an endpoint.
+func limits(l *limiter, concurrent echo.MiddlewareFunc) []echo.MiddlewareFunc {
+	var res []echo.MiddlewareFunc
+	if l != nil {
+		res = append(res, l.middleware)
+	}
+	if concurrent != nil {
+		res = append(res, concurrent)
+	}
+	return res
+}
+
+// retryAfter converts a delay to whole seconds for Retry-After header.
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// checkNamesNum returns an error if a request has more name-strings than
+// allowed. Zero maximum means no limit.
+func checkNamesNum(num, maxNum int) error {
+	if maxNum > 0 && num > maxNum {
+		return echo.NewHTTPError(
+			http.StatusRequestEntityTooLarge,
+			"too many names, maximum is "+strconv.Itoa(maxNum),
+		)
+	}
+	return nil
+}
+
+// bodyLimit returns the maximal size of a request body for the limit of
+// name-strings per request, so too large requests are rejected before they
+// are read. Empty string means no limit.
+func bodyLimit(maxNames int) string {
+	if maxNames <= 0 {
+		return ""
+	}
+	return strconv.Itoa(maxNames*bytesPerName/1024+64) + "K"
+}
+
+// ipExtractor returns a function that finds IP addresses of clients.
+// X-Forwarded-For header is used only for requests from trusted proxies,
+// otherwise clients could pick any address to avoid rate limits.
+func ipExtractor(proxies []string) (echo.IPExtractor, error) {
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	opts := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, v := range proxies {
+		ipNet, err := parseIPNet(v)
+		if err != nil {
+			return nil, fmt.Errorf("rest.ipExtractor: %w", err)
+		}
+		opts = append(opts, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(opts...), nil
+}
+
+// parseIPNet converts an IP address or a CIDR range to a network.
+func parseIPNet(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		_, res, err := net.ParseCIDR(s)
+		return res, err
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("wrong IP address %q", s)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	keys := []config.APIKey{
+		{Key: "key1"},
+		{Key: "key2", RateLimitVerify: 120},
+	}
+	perMin := func(k config.APIKey) int { return k.RateLimitVerify }
+	cl := &clients{keys: map[string]config.APIKey{
+		"key1": keys[0], "key2": keys[1],
+	}}
+	e.Use(cl.middleware)
+	e.GET("/", ok, limits(newLimiter(2, perMin, keys), nil)...)
+
+	get := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Remaining"))
+	rec = get("")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = get("")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+
+	// clients with API keys have their own limits
+	rec = get("key1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	rec = get("key2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "120", rec.Header().Get("X-RateLimit-Limit"))
+
+	rec = get("unknown")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// without configured keys random keys do not give new limits
+	e = echo.New()
+	e.Use((&clients{}).middleware)
+	e.GET("/", ok, limits(newLimiter(1, perMin, nil), nil)...)
+	rec = get("random1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = get("random2")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestIPExtractor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+	req.Header.Set(echo.HeaderXRealIP, "203.0.113.8")
+
+	ext, err := ipExtractor(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "192.0.2.1", ext(req))
+
+	ext, err = ipExtractor([]string{"198.51.100.0/24"})
+	assert.Nil(t, err)
+	assert.Equal(t, "192.0.2.1", ext(req))
+
+	ext, err = ipExtractor([]string{"192.0.2.1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "203.0.113.7", ext(req))
+
+	_, err = ipExtractor([]string{"not an IP"})
+	assert.NotNil(t, err)
+}
+
+func TestNoLimiter(t *testing.T) {
+	perMin := func(k config.APIKey) int { return k.RateLimitSearch }
+	assert.Nil(t, newLimiter(0, perMin, nil))
+	assert.Nil(t, newLimiter(0, perMin, []config.APIKey{{Key: "k"}}))
+	l := newLimiter(0, perMin, []config.APIKey{{Key: "k", RateLimitSearch: 1}})
+	assert.NotNil(t, l)
+}
+
+func TestConcurrency(t *testing.T) {
+	e := echo.New()
+	start, done := make(chan struct{}), make(chan struct{})
+	slow := func(c echo.Context) error {
+		close(start)
+		<-done
+		return c.String(http.StatusOK, "ok")
+	}
+	e.GET("/", slow, limits(nil, concurrency(1))...)
+
+	go e.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/", nil))
+	<-start
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	close(done)
+}
+
+func TestCheckNamesNum(t *testing.T) {
+	assert.Nil(t, checkNamesNum(1000, 0))
+	assert.Nil(t, checkNamesNum(10, 10))
+	err := checkNamesNum(11, 10)
+	he, ok := err.(*echo.HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, he.Code)
+}
+
+func TestBodyLimit(t *testing.T) {
+	assert.Empty(t, bodyLimit(0))
+	assert.Equal(t, "74K", bodyLimit(10))
+
+	e := echo.New()
+	e.Use(middleware.BodyLimit(bodyLimit(1)))
+	e.POST("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	body := strings.NewReader(strings.Repeat("a", 100*1024))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", body))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
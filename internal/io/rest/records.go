@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/labstack/echo/v4"
+)
+
+// recordGET finds names by an identifier of a data-source record. The
+// data-source can be given by its ID, UUID or short title.
+func recordGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		dsID, _ := url.PathUnescape(c.Param("dataSourceID"))
+		ds, err := visibleDataSource(c, gn, dsID)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.recordGET: %w", err)
+		}
+
+		idType, err := record.NewIDType(c.QueryParam("id_type"))
+		if err != nil {
+			return newParamError("id_type",
+				"must be record_id, local_id or global_id")
+		}
+		id, _ := url.PathUnescape(c.Param("recordID"))
+		var langs []string
+		if v := c.QueryParam("vernaculars"); v != "" {
+			langs = strings.Split(v, "|")
+		}
+
+		inp := record.Input{
+			DataSourceID: ds.ID,
+			IDs:          []string{id},
+			IDType:       idType,
+			Vernaculars:  langs,
+		}
+		return records(c, gn, inp)
+	}
+}
+
+// recordsPOST finds names by identifiers of records of a data-source.
+func recordsPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		var inp record.Input
+		err := c.Bind(&inp)
+		if errors.Is(err, record.ErrIDType) {
+			return newParamError("idType",
+				"must be record_id, local_id or global_id")
+		}
+		if err != nil {
+			return err
+		}
+		if len(inp.IDs) == 0 {
+			return newParamError("ids", "at least one ID is required")
+		}
+		err = checkNamesNum(len(inp.IDs), gn.GetConfig().MaxNamesPerRequest)
+		if err != nil {
+			return err
+		}
+		slog.Info("Records",
+			slog.Int("recordsNum", len(inp.IDs)),
+			slog.Int("dataSourceID", inp.DataSourceID),
+			slog.String("idType", inp.IDType.String()),
+		)
+		return records(c, gn, inp)
+	}
+}
+
+func records(c echo.Context, gn gnames.GNames, inp record.Input) error {
+	ctx, cancel := getContext(c)
+	defer cancel()
+
+	res, err := gn.Records(ctx, inp)
+	if errors.Is(err, dsrc.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("rest.records: %w", err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
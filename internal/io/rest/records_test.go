@@ -0,0 +1,72 @@
+package rest_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/record"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	assert := assert.New(t)
+	var ns vlib.NameStringOutput
+	resp := makeGetRequest(t, "name_strings/0eeccd70-eaf2-5c51-ad8b-46cfb3db1645")
+	decodeJSONResponse(t, readResponseBody(t, resp), &ns)
+	require.NotNil(t, ns.Name)
+	require.NotNil(t, ns.Name.BestResult)
+	best := ns.Name.BestResult
+
+	var res record.Output
+	resp = makeGetRequest(t, "records/1/"+url.PathEscape(best.RecordID)+
+		"?vernaculars=eng")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	require.Len(t, res.Records, 1)
+	rec := res.Records[0]
+	assert.Equal(best.RecordID, rec.ID)
+	assert.False(rec.NotFound)
+	require.NotEmpty(t, rec.Results)
+	assert.Equal(best.MatchedName, rec.Results[0].MatchedName)
+	assert.Equal(best.CurrentName, rec.Results[0].CurrentName)
+	assert.Equal(best.ClassificationPath, rec.Results[0].ClassificationPath)
+	assert.NotEmpty(rec.Vernaculars)
+
+	// the short title of a data-source works as well
+	resp = makeGetRequest(t, "records/"+url.PathEscape("catalogue of life")+"/"+
+		url.PathEscape(best.RecordID))
+	assert.Equal(http.StatusOK, resp.StatusCode)
+
+	input := record.Input{
+		DataSourceID: 1,
+		IDs:          []string{"no such record", best.RecordID},
+	}
+	resp = makePostRequest(t, "records", input)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	assert.Equal(2, res.RecordsNum)
+	assert.Equal(1, res.NotFoundNum)
+	assert.True(res.Records[0].NotFound)
+	assert.Equal(best.RecordID, res.Records[1].ID)
+	assert.NotEmpty(res.Records[1].Results)
+}
+
+func TestRecordsErrors(t *testing.T) {
+	assert := assert.New(t)
+	resp := makeGetRequest(t, "records/1/abc?id_type=tsn")
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeGetRequest(t, "records/100000/abc")
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp = makePostRequest(t, "records", record.Input{DataSourceID: 1})
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp = makePostRequest(t, "records", map[string]any{
+		"dataSourceId": 1, "ids": []string{"abc"}, "idType": "tsn",
+	})
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
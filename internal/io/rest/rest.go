@@ -5,14 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
 	"github.com/gnames/gnames/pkg/ent/recon"
+	"github.com/gnames/gnames/pkg/ent/srch"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnfmt"
 	"github.com/gnames/gnlib/ent/reconciler"
 	vlib "github.com/gnames/gnlib/ent/verifier"
@@ -31,50 +40,122 @@ var (
 )
 
 // Run starts HTTP/1 service on given port for scientific names verification.
-func Run(gn gnames.GNames, port int) {
+// The service stops when the context is cancelled. In-flight requests get
+// ShutdownTimeoutSec seconds to finish, after that their contexts are
+// cancelled. Run returns nil after a graceful shutdown.
+func Run(ctx context.Context, gn gnames.GNames, port int) error {
 	slog.Info("Starting HTTP API server", slog.Int("port", port))
-	e := echo.New()
-	e.Use(middleware.Gzip())
-	e.Use(middleware.CORS())
+	cfg := gn.GetConfig()
+	e, err := newServer(gn, cfg)
+	if err != nil {
+		return fmt.Errorf("rest.Run: %w", err)
+	}
 
-	e.GET("/", info)
-	e.GET("/api", info)
-	e.GET("/api/", info)
-	e.GET("/api/v1", info)
-	e.GET("/api/v1/", info)
-	e.GET(apiPath, info)
-	e.GET(apiPath+"ping", ping)
-	e.GET(apiPath+"version", ver(gn))
-	e.GET(apiPath+"data_sources", dataSources(gn))
-	e.GET(apiPath+"data_sources/:id", oneDataSource(gn))
-	e.GET(apiPath+"name_strings", nameInfoGET(gn))
-	e.GET(apiPath+"name_strings/", nameInfoGET(gn))
-	e.GET(apiPath+"name_strings/:id", nameGET(gn))
-	// same as verify, kept for backward compatibility
-	e.POST(apiPath+"verifications", verificationPOST(gn))
-	// same as verify, kept for backward compatibility
-	e.GET(apiPath+"verifications/:names", verificationGET(gn))
-	e.POST(apiPath+"verify", verificationPOST(gn))
-	e.GET(apiPath+"verify/:names", verificationGET(gn))
-	e.POST(apiPath+"search", searchPOST(gn))
-	e.GET(apiPath+"search/:query", searchGET(gn))
-	e.GET(apiPath+"reconcile", reconcileGET(gn))
-	e.POST(apiPath+"reconcile", reconcilePOST(gn))
-	e.GET(apiPath+"reconcile/properties", propertiesGET(gn))
+	if m := cfg.DataSourcesReloadMin; m > 0 {
+		go reloadDataSourcesEvery(ctx, gn, time.Duration(m)*time.Minute)
+	}
+
+	// reqCtx is the parent of contexts of all requests, it is cancelled
+	// when the shutdown timeout is over.
+	reqCtx, cancelReqs := context.WithCancel(context.Background())
+	defer cancelReqs()
 
 	addr := fmt.Sprintf(":%d", port)
 	s := &http.Server{
 		Addr:         addr,
 		ReadTimeout:  5 * time.Minute,
 		WriteTimeout: 5 * time.Minute,
+		BaseContext:  func(net.Listener) context.Context { return reqCtx },
+	}
+
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- e.StartServer(s)
+	}()
+
+	select {
+	case err := <-chErr:
+		return fmt.Errorf("rest.Run: %w", err)
+	case <-ctx.Done():
+	}
+
+	timeout := time.Duration(cfg.ShutdownTimeoutSec) * time.Second
+	slog.Info("Stopping HTTP API server, draining in-flight requests",
+		slog.Duration("timeout", timeout),
+	)
+	shutCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = e.Shutdown(shutCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.Warn("Shutdown timeout is over, cancelling in-flight requests")
+		cancelReqs()
+		err = e.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("rest.Run: %w", err)
+	}
+	slog.Info("HTTP API server stopped")
+	return nil
+}
+
+// newServer creates an echo instance with middlewares and routes of the
+// API. Handlers get the GNames instance, but it is not used until they
+// are called.
+func newServer(gn gnames.GNames, cfg config.Config) (*echo.Echo, error) {
+	e := echo.New()
+	e.HTTPErrorHandler = httpErrorHandler(e)
+	var err error
+	e.IPExtractor, err = ipExtractor(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	if l := bodyLimit(cfg.MaxNamesPerRequest); l != "" {
+		e.Use(middleware.BodyLimit(l))
+	}
+	e.Use(middleware.Gzip())
+	e.Use(middleware.CORS())
+
+	cl, err := newClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.Use(cl.middleware)
+
+	var verifConc echo.MiddlewareFunc
+	if cfg.MaxConcurrentVerifications > 0 {
+		verifConc = concurrency(cfg.MaxConcurrentVerifications)
+	}
+	keys := slices.Collect(maps.Values(cl.keys))
+	lims := limiters{
+		verify: limits(newLimiter(cfg.RateLimitVerify,
+			func(k config.APIKey) int { return k.RateLimitVerify }, keys,
+		), verifConc),
+		search: limits(newLimiter(cfg.RateLimitSearch,
+			func(k config.APIKey) int { return k.RateLimitSearch }, keys,
+		), nil),
+		reconcile: limits(newLimiter(cfg.RateLimitReconcile,
+			func(k config.APIKey) int { return k.RateLimitReconcile }, keys,
+		), nil),
 	}
-	e.Logger.Fatal(e.StartServer(s))
+
+	var doc apiDoc
+	public := slices.Concat(publicRoutes(gn, lims, &doc), v2Routes(gn, lims))
+	addRoutes(e.Add, public)
+	admin := addAdmin(e, gn, cfg.AdminToken)
+
+	doc.spec, err = newOpenAPI(public, admin)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
 }
 
 func info(c echo.Context) error {
 	return c.String(http.StatusOK,
 		`The API is described at
-https://apidoc.globalnames.org/gnames`)
+`+apiPath+`docs, OpenAPI specification is at
+`+apiPath+`openapi.json`)
 }
 
 func ping(c echo.Context) error {
@@ -90,23 +171,100 @@ func ver(gn gnames.GNames) func(echo.Context) error {
 
 func dataSources(gn gnames.GNames) func(echo.Context) error {
 	return func(c echo.Context) error {
-		dataSources := gn.DataSources()
-		return c.JSON(http.StatusOK, dataSources)
+		f, err := dataSourcesFilter(c)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		acc := access.FromContext(c.Request().Context())
+		if f == nil {
+			return c.JSON(http.StatusOK, acc.FilterDataSources(gn.DataSources()))
+		}
+		return c.JSON(http.StatusOK, acc.FilterDataSources(gn.FilterDataSources(*f)))
 	}
 }
 
+// dataSourcesFilter creates a filter from query parameters. It returns nil
+// if there are no filtering parameters.
+func dataSourcesFilter(c echo.Context) (*dsrc.Filter, error) {
+	text := c.QueryParam("filter")
+	curStr := c.QueryParam("curation")
+	taxonStr := c.QueryParam("has_taxon_data")
+	if text == "" && curStr == "" && taxonStr == "" {
+		return nil, nil
+	}
+
+	res := dsrc.Filter{Text: text}
+	if curStr != "" {
+		for v := range strings.SplitSeq(curStr, "|") {
+			cl, ok := dsrc.ParseCuration(v)
+			if !ok {
+				return nil, fmt.Errorf("unknown curation level %q", v)
+			}
+			res.Curation = append(res.Curation, cl)
+		}
+	}
+	if taxonStr != "" {
+		hasTaxon, err := strconv.ParseBool(taxonStr)
+		if err != nil {
+			return nil, fmt.Errorf("has_taxon_data must be true or false")
+		}
+		res.HasTaxonData = &hasTaxon
+	}
+	return &res, nil
+}
+
 func oneDataSource(gn gnames.GNames) func(echo.Context) error {
 	return func(c echo.Context) error {
-		idStr := c.Param("id")
-		id, err := strconv.Atoi(idStr)
+		id, _ := url.PathUnescape(c.Param("id"))
+		ds, err := visibleDataSource(c, gn, id)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.oneDataSource: %w", err)
+		}
+		return c.JSON(http.StatusOK, ds)
+	}
+}
+
+// visibleDataSource finds a data-source by its identifier. Data-sources
+// hidden from the client are not found.
+func visibleDataSource(
+	c echo.Context,
+	gn gnames.GNames,
+	id string,
+) (*vlib.DataSource, error) {
+	ds, err := gn.DataSource(id)
+	if err != nil {
+		return nil, err
+	}
+	if !access.FromContext(c.Request().Context()).Allowed(ds.ID) {
+		return nil, fmt.Errorf("data-source %q: %w", id, dsrc.ErrNotFound)
+	}
+	return ds, nil
+}
+
+func dataSourceStats(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		id, _ := url.PathUnescape(c.Param("id"))
+		ds, err := visibleDataSource(c, gn, id)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		if err != nil {
-			return fmt.Errorf("rest.oneDataSource: atoi: %w", err)
+			return fmt.Errorf("rest.dataSourceStats: %w", err)
+		}
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		res, err := gn.DataSourceStats(ctx, ds.ID, false)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		}
-		dataSources := gn.DataSources(id)
-		if len(dataSources) == 0 {
-			return errors.New("rest.oneDataSource: no data sources")
+		if err != nil {
+			return fmt.Errorf("rest.dataSourceStats: %w", err)
 		}
-		return c.JSON(http.StatusOK, dataSources[0])
+		return c.JSON(http.StatusOK, res)
 	}
 }
 
@@ -114,7 +272,9 @@ func reconcileGET(gn gnames.GNames) func(echo.Context) error {
 	return func(c echo.Context) error {
 		enc := gnfmt.GNjson{}
 		if c.QueryParam("extend") != "" {
-			res, err := extend(gn, c.QueryParam("extend"))
+			res, err := extend(
+				c.Request().Context(), gn, c.QueryParam("extend"),
+			)
 			if err != nil {
 				return fmt.Errorf("rest.reconcileGET: %w", err)
 			}
@@ -132,7 +292,11 @@ func reconcileGET(gn gnames.GNames) func(echo.Context) error {
 		if err != nil {
 			return fmt.Errorf("rest.reconcileGET: %w", err)
 		}
-		res, err := reconcile(gn, params)
+		err = checkNamesNum(len(params), gn.GetConfig().MaxNamesPerRequest)
+		if err != nil {
+			return err
+		}
+		res, err := reconcile(c.Request().Context(), gn, params)
 		if err != nil {
 			return fmt.Errorf("rest.reconcileGET: %w", err)
 		}
@@ -141,7 +305,11 @@ func reconcileGET(gn gnames.GNames) func(echo.Context) error {
 	}
 }
 
-func extend(gn gnames.GNames, q string) (reconciler.ExtendOutput, error) {
+func extend(
+	ctx context.Context,
+	gn gnames.GNames,
+	q string,
+) (reconciler.ExtendOutput, error) {
 	var res reconciler.ExtendOutput
 	enc := gnfmt.GNjson{}
 	var params reconciler.ExtendQuery
@@ -149,7 +317,7 @@ func extend(gn gnames.GNames, q string) (reconciler.ExtendOutput, error) {
 	if err != nil {
 		return res, fmt.Errorf("rest.extend: %w", err)
 	}
-	return gn.ExtendReconcile(params)
+	return gn.ExtendReconcile(ctx, params)
 }
 
 func reconcilePOST(gn gnames.GNames) func(echo.Context) error {
@@ -169,7 +337,7 @@ func reconcilePOST(gn gnames.GNames) func(echo.Context) error {
 				var extRes reconciler.ExtendOutput
 				err = enc.Decode([]byte(ext), &params)
 				if err == nil {
-					extRes, err = gn.ExtendReconcile(params)
+					extRes, err = gn.ExtendReconcile(ctx, params)
 				}
 				if err == nil {
 					err = c.JSON(http.StatusOK, extRes)
@@ -183,7 +351,12 @@ func reconcilePOST(gn gnames.GNames) func(echo.Context) error {
 
 			err = enc.Decode(q, &params)
 			if err == nil {
-				res, err = reconcile(gn, params)
+				err = checkNamesNum(
+					len(params), gn.GetConfig().MaxNamesPerRequest,
+				)
+			}
+			if err == nil {
+				res, err = reconcile(ctx, gn, params)
 			}
 
 			if err == nil {
@@ -206,22 +379,23 @@ func reconcilePOST(gn gnames.GNames) func(echo.Context) error {
 }
 
 func reconcile(
+	ctx context.Context,
 	gn gnames.GNames,
 	params map[string]reconciler.Query,
 ) (reconciler.Output, error) {
 	var err error
 	var res reconciler.Output
-	var verified vlib.Output
+	var verified verif.Output
 	var names, ids []string
 	for k, v := range params {
 		ids = append(ids, k)
 		names = append(names, v.Query)
 	}
-	inp := vlib.Input{
+	inp := verif.Input{Input: vlib.Input{
 		NameStrings:    names,
 		WithAllMatches: true,
-	}
-	verified, err = gn.Verify(context.Background(), inp)
+	}}
+	verified, err = gn.VerifyWithOptions(ctx, inp)
 	if err != nil {
 		return res, fmt.Errorf("rest.reconcile: %w", err)
 	}
@@ -338,6 +512,12 @@ Example:
 
 https://verifier.globalnames.org/api/v1/name_strings/0eeccd70-eaf2-5c51-ad8b-46cfb3db1645?all_matches=true&data_sources=1,11
 
+Many name-strings can be resolved at once with a POST request to this
+endpoint. The JSON body contains "ids" (UUIDs or name-strings), and
+optional "dataSources", "withAllMatches" and "withLexicalGroups" fields.
+Results follow the order of "ids", name-strings without records are marked
+as "notFound".
+
 The list of DataSource IDs can be found at
 
 https://verifier.globalnames.org/api/v1/data_sources
@@ -375,13 +555,69 @@ func nameGET(gn gnames.GNames) func(echo.Context) error {
 			WithAllMatches: matches,
 		}
 
-		name, err := gn.NameByID(params, false)
+		name, err := gn.NameByID(c.Request().Context(), params, false)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		if err != nil {
 			return fmt.Errorf("rest.nameGET: %w", err)
 		}
 
-		return c.JSON(http.StatusOK, name)
+		var names []vlib.Name
+		if name.Name != nil {
+			names = []vlib.Name{*name.Name}
+		}
+		return respond(c, name, names, matches)
+	}
+}
+
+func nameStringsPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		var inp verif.NameStringsInput
+		if err := c.Bind(&inp); err != nil {
+			return err
+		}
+		if len(inp.IDs) == 0 {
+			return newParamError("ids", "at least one ID is required")
+		}
+		err := checkNamesNum(len(inp.IDs), gn.GetConfig().MaxNamesPerRequest)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		res, err := gn.NameStrings(ctx, inp)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.nameStringsPOST: %w", err)
+		}
+
+		slog.Info("Name-strings",
+			slog.Int("namesNum", len(inp.IDs)),
+			slog.String("example", inp.IDs[0]),
+			slog.String("method", "POST"),
+		)
+		return respond(c, res, nameStringsNames(res), inp.WithAllMatches)
+	}
+}
+
+// nameStringsNames returns names of a batch lookup for flat outputs.
+// Name-strings without records become names without matches, so rows
+// keep the order of the input.
+func nameStringsNames(res verif.NameStringsOutput) []vlib.Name {
+	names := make([]vlib.Name, len(res.Names))
+	for i, v := range res.Names {
+		if v.Name != nil {
+			names[i] = *v.Name
+			continue
+		}
+		names[i] = vlib.Name{ID: v.ID, Name: v.Input, MatchType: vlib.NoMatch}
 	}
+	return names
 }
 
 func verificationPOST(gn gnames.GNames) func(echo.Context) error {
@@ -394,13 +630,20 @@ func verificationPOST(gn gnames.GNames) func(echo.Context) error {
 			defer close(chErr)
 
 			var err error
-			var verified vlib.Output
-			var params vlib.Input
+			var verified verif.Output
+			var params verif.Input
 
 			err = c.Bind(&params)
-
 			if err == nil {
-				verified, err = gn.Verify(ctx, params)
+				err = checkNamesNum(
+					len(params.NameStrings), gn.GetConfig().MaxNamesPerRequest,
+				)
+			}
+			if err == nil {
+				verified, err = gn.VerifyWithOptions(ctx, params)
+			}
+			if isVerifInputErr(err) {
+				err = echo.NewHTTPError(http.StatusBadRequest, err.Error())
 			}
 
 			if l := len(params.NameStrings); l > 0 {
@@ -413,7 +656,9 @@ func verificationPOST(gn gnames.GNames) func(echo.Context) error {
 			}
 
 			if err == nil {
-				err = c.JSON(http.StatusOK, verified)
+				err = respondVerif(c, gn, verified, verified,
+					params.WithAllMatches,
+				)
 			}
 
 			chErr <- err
@@ -435,38 +680,55 @@ func verificationGET(gn gnames.GNames) func(echo.Context) error {
 	return func(c echo.Context) error {
 		nameStr, _ := url.QueryUnescape(c.Param("names"))
 		names := strings.Split(nameStr, "|")
+		err := checkNamesNum(len(names), gn.GetConfig().MaxNamesPerRequest)
+		if err != nil {
+			return err
+		}
+		var vernLangs []string
 		vernStr, _ := url.QueryUnescape(c.QueryParam("vernaculars"))
-		vernLangs := strings.Split(vernStr, "|")
+		if vernStr != "" {
+			vernLangs = strings.Split(vernStr, "|")
+		}
+		var countries []string
+		countriesStr, _ := url.QueryUnescape(c.QueryParam("vernacular_countries"))
+		if countriesStr != "" {
+			countries = strings.Split(countriesStr, "|")
+		}
 		dsStr, _ := url.QueryUnescape(c.QueryParam("data_sources"))
-		capitalize := c.QueryParam("capitalize") == "true"
-		spGrp := c.QueryParam("species_group") == "true"
-		stats := c.QueryParam("stats") == "true"
-		fuzzyRelaxed := c.QueryParam("fuzzy_relaxed") == "true"
-		fuzzyUni := c.QueryParam("fuzzy_uninomial") == "true"
 		mainTxnThresholdStr := c.QueryParam("main_taxon_threshold")
-		matches := c.QueryParam("all_matches") == "true"
+		suggestionsNum, _ := strconv.Atoi(c.QueryParam("suggestions_num"))
+		sort := c.QueryParam("sort")
 
 		mainTxnThreshold, _ := strconv.ParseFloat(mainTxnThresholdStr, 64)
-		var ds []int
+		statsDS, _ := strconv.Atoi(c.QueryParam("stats_data_source"))
+		var ds dsrc.Identifiers
 		for v := range strings.SplitSeq(dsStr, "|") {
-			if id, err := strconv.Atoi(v); err == nil {
-				ds = append(ds, id)
+			if v = strings.TrimSpace(v); v != "" {
+				ds = append(ds, v)
 			}
 		}
 
-		params := vlib.Input{
-			NameStrings:             names,
-			Vernaculars:             vernLangs,
-			DataSources:             ds,
-			WithCapitalization:      capitalize,
-			WithAllMatches:          matches,
-			WithStats:               stats,
-			WithSpeciesGroup:        spGrp,
-			WithRelaxedFuzzyMatch:   fuzzyRelaxed,
-			WithUninomialFuzzyMatch: fuzzyUni,
-			MainTaxonThreshold:      float32(mainTxnThreshold),
+		params := verif.Input{
+			Input: vlib.Input{
+				NameStrings:        names,
+				Vernaculars:        vernLangs,
+				MainTaxonThreshold: float32(mainTxnThreshold),
+			},
+			DataSources:         ds,
+			VernacularCountries: countries,
+			StatsDataSource:     statsDS,
+			SuggestionsNum:      suggestionsNum,
+			Sort:                sort,
+		}
+		// version 1 treats all values except `true` as false.
+		for _, v := range verifFlags(&params) {
+			*v.val = c.QueryParam(v.name) == "true"
+		}
+		var verified verif.Output
+		verified, err = gn.VerifyWithOptions(c.Request().Context(), params)
+		if isVerifInputErr(err) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
-		verified, err := gn.Verify(context.Background(), params)
 		if err != nil {
 			return fmt.Errorf("rest.verificationGET: %w", err)
 		}
@@ -478,24 +740,29 @@ func verificationGET(gn gnames.GNames) func(echo.Context) error {
 				slog.String("method", "GET"),
 			)
 		}
-		return c.JSON(http.StatusOK, verified)
+		return respondVerif(c, gn, verified, verified, params.WithAllMatches)
 	}
 }
 
 func searchGET(gn gnames.GNames) func(echo.Context) error {
 	return func(c echo.Context) error {
 		q, _ := url.QueryUnescape(c.Param("query"))
+		maxEditDist, _ := strconv.Atoi(c.QueryParam("max_edit_dist"))
 		gnq := gnquery.New()
-		inp := gnq.Parse(q)
-		res := gn.Search(context.Background(), inp)
+		inp := srch.Input{
+			Input:       gnq.Parse(q),
+			MaxEditDist: maxEditDist,
+		}
+		res := gn.Search(c.Request().Context(), inp)
 
 		slog.Info("Search",
 			slog.String("query", q),
+			slog.Int("maxEditDist", maxEditDist),
 			slog.String("parsedBy", "REST API"),
 			slog.String("method", "GET"),
 		)
 
-		return c.JSON(http.StatusOK, res)
+		return respond(c, res, res.Names, inp.WithAllMatches)
 	}
 }
 
@@ -510,11 +777,11 @@ func searchPOST(gn gnames.GNames) func(echo.Context) error {
 
 			var err error
 			var res search.Output
-			var params search.Input
+			var params srch.Input
 
 			err = c.Bind(&params)
 
-			params = gnquery.New().Process(params)
+			params.Input = gnquery.New().Process(params.Input)
 
 			if err == nil {
 				res = gn.Search(ctx, params)
@@ -523,7 +790,7 @@ func searchPOST(gn gnames.GNames) func(echo.Context) error {
 					slog.String("parsedBy", "REST API"),
 					slog.String("method", "POST"),
 				)
-				err = c.JSON(http.StatusOK, res)
+				err = respond(c, res, res.Names, params.WithAllMatches)
 			}
 
 			// should not get here if all is OK
@@ -542,8 +809,49 @@ func searchPOST(gn gnames.GNames) func(echo.Context) error {
 	}
 }
 
+func vernSearchGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		q, _ := url.QueryUnescape(c.Param("query"))
+		langStr, _ := url.QueryUnescape(c.QueryParam("languages"))
+		prefix := c.QueryParam("prefix") == "true"
+		var langs []string
+		for v := range strings.SplitSeq(langStr, "|") {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v != "" {
+				langs = append(langs, v)
+			}
+		}
+
+		inp := vern.SearchInput{
+			Query:      q,
+			Languages:  langs,
+			WithPrefix: prefix,
+		}
+		res := gn.SearchVernaculars(ctx, inp)
+
+		slog.Info("Vernacular search",
+			slog.String("query", q),
+			slog.String("parsedBy", "REST API"),
+			slog.String("method", "GET"),
+		)
+
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
 func getContext(c echo.Context) (ctx context.Context, cancel func()) {
 	ctx = c.Request().Context()
 	ctx, cancel = context.WithTimeout(ctx, 5*time.Minute)
 	return ctx, cancel
 }
+
+// isVerifInputErr returns true if verification failed because of invalid
+// options of the request.
+func isVerifInputErr(err error) bool {
+	return errors.Is(err, dsrc.ErrNotFound) ||
+		errors.Is(err, verif.ErrSort) ||
+		errors.Is(err, verif.ErrStatsDataSource)
+}
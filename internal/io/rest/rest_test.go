@@ -10,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/verif"
 	"github.com/gnames/gnfmt"
 	"github.com/gnames/gnlib"
 	"github.com/gnames/gnlib/ent/gnvers"
@@ -54,6 +58,25 @@ func makeGetRequest(t *testing.T, endpoint string) *http.Response {
 	return resp
 }
 
+// makeAdminRequest sends a request with the admin token to the admin API
+// and returns the response.
+func makeAdminRequest(
+	t *testing.T,
+	method, endpoint, token string,
+) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, restURL+"admin/"+endpoint, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
 // readResponseBody reads and returns the response body
 func readResponseBody(t *testing.T, resp *http.Response) []byte {
 	t.Helper()
@@ -118,6 +141,29 @@ func TestVersion(t *testing.T) {
 	assert.Regexp(t, `^v\d+\.\d+\.\d+`, response.Version)
 }
 
+// TestHealth checks liveness and readiness endpoints.
+func TestHealth(t *testing.T) {
+	host := getConfig().GnamesHostURL
+	resp, err := http.Get(host + "/healthz")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = http.Get(host + "/readyz")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := readResponseBody(t, resp)
+
+	var res admin.Readiness
+	decodeJSONResponse(t, body, &res)
+	assert.True(t, res.Ready)
+	assert.Len(t, res.Components, 3)
+	for _, c := range res.Components {
+		assert.True(t, c.Ready, c.Name)
+		assert.Empty(t, c.Error)
+	}
+}
+
 // TestVerifyExact tests the verification endpoint with exact matches
 func TestVerifyExact(t *testing.T) {
 	names := []string{
@@ -623,6 +669,131 @@ func TestOneDataSource(t *testing.T) {
 	assert.Equal(t, "https://eol.org", ds.WebsiteURL)
 }
 
+// TestDataSourceLookup checks finding data-sources by short titles,
+// and filtering of data-sources.
+func TestDataSourceLookup(t *testing.T) {
+	resp := makeGetRequest(t, "data_sources/"+url.PathEscape("catalogue of life"))
+	body := readResponseBody(t, resp)
+	var ds vlib.DataSource
+	decodeJSONResponse(t, body, &ds)
+	assert.Equal(t, 1, ds.ID)
+
+	resp = makeGetRequest(t, "data_sources/9999")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	resp = makeGetRequest(t, "data_sources?filter=catalogue&curation=curated")
+	body = readResponseBody(t, resp)
+	var dss []vlib.DataSource
+	decodeJSONResponse(t, body, &dss)
+	require.NotEmpty(t, dss)
+	assert.Equal(t, 1, dss[0].ID)
+	for _, v := range dss {
+		assert.Equal(t, vlib.Curated, v.Curation)
+	}
+
+	query := url.PathEscape("Bubo bubo") + "?data_sources=" +
+		url.QueryEscape("Catalogue of Life")
+	res := getVerificationRequest(t, query)
+	require.Len(t, res.Names, 1)
+	require.NotNil(t, res.Names[0].BestResult)
+	assert.Equal(t, 1, res.Names[0].BestResult.DataSourceID)
+
+	resp = makeGetRequest(t, "verifications/Bubo?data_sources=no_such_source")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+// TestReloadDataSources checks reloading of data-sources metadata.
+func TestReloadDataSources(t *testing.T) {
+	token := getConfig().AdminToken
+	if token == "" {
+		t.Skip("GN_ADMIN_TOKEN is not set, admin API is disabled")
+	}
+	resp := makeAdminRequest(t, http.MethodPost, "data_sources/reload", token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := readResponseBody(t, resp)
+
+	var changes dsrc.Changes
+	decodeJSONResponse(t, body, &changes)
+	assert.True(t, changes.IsEmpty())
+}
+
+// TestAdmin checks authentication and endpoints of the admin API.
+func TestAdmin(t *testing.T) {
+	token := getConfig().AdminToken
+	if token == "" {
+		t.Skip("GN_ADMIN_TOKEN is not set, admin API is disabled")
+	}
+
+	resp := makeAdminRequest(t, http.MethodGet, "status", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = makeAdminRequest(t, http.MethodGet, "status", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeAdminRequest(t, http.MethodGet, "status", token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := readResponseBody(t, resp)
+	var status admin.Status
+	decodeJSONResponse(t, body, &status)
+	assert.Positive(t, status.DataSourcesNum)
+	assert.Positive(t, status.DBPool.MaxConns)
+
+	resp = makeAdminRequest(t, http.MethodGet, "config", token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = readResponseBody(t, resp)
+	var cfg config.Config
+	decodeJSONResponse(t, body, &cfg)
+	assert.NotEqual(t, token, cfg.AdminToken)
+	assert.NotEqual(t, getConfig().PgPass, cfg.PgPass)
+
+	resp = makeAdminRequest(t, http.MethodPost, "data_sources/stats/flush", token)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body = readResponseBody(t, resp)
+	var flush map[string]int
+	decodeJSONResponse(t, body, &flush)
+	assert.Contains(t, flush, "flushedNum")
+}
+
+// TestDataSourceStats checks data_sources/{id}/stats endpoint.
+func TestDataSourceStats(t *testing.T) {
+	resp := makeGetRequest(t, "data_sources/180/stats")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body := readResponseBody(t, resp)
+
+	var stats dsrc.Stats
+	decodeJSONResponse(t, body, &stats)
+
+	assert.Equal(t, 180, stats.DataSourceID)
+	assert.Positive(t, stats.RecordsNum)
+	assert.Positive(t, stats.NameStringsNum)
+	assert.Equal(t, stats.RecordsNum, stats.AcceptedNum+stats.SynonymsNum)
+	assert.NotEmpty(t, stats.Ranks)
+	assert.NotEmpty(t, stats.ComputedAt)
+
+	// cached results are returned until refresh is requested
+	resp = makeGetRequest(t, "data_sources/180/stats")
+	body = readResponseBody(t, resp)
+	var cached dsrc.Stats
+	decodeJSONResponse(t, body, &cached)
+	assert.Equal(t, stats.ComputedAt, cached.ComputedAt)
+
+	// refresh is available only from the admin API
+	if token := getConfig().AdminToken; token != "" {
+		resp = makeAdminRequest(t, http.MethodPost, "data_sources/180/stats", token)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body = readResponseBody(t, resp)
+		var fresh dsrc.Stats
+		decodeJSONResponse(t, body, &fresh)
+		assert.Equal(t, stats.RecordsNum, fresh.RecordsNum)
+		assert.GreaterOrEqual(t, fresh.ComputedAt, stats.ComputedAt)
+	}
+
+	resp = makeGetRequest(t, "data_sources/9999/stats")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+}
+
 // VernacularGET checks `vernaculars` attribute with GET.
 func TestVernacularGET(t *testing.T) {
 	tests := []struct {
@@ -800,3 +971,179 @@ func TestBestResults(t *testing.T) {
 		})
 	}
 }
+
+func TestLexicalGroups(t *testing.T) {
+	assert := assert.New(t)
+	request := verif.Input{
+		Input: vlib.Input{
+			NameStrings: []string{"Solanum tuberosum", "Bubo bubo"},
+		},
+		WithLexicalGroups: true,
+	}
+	resp := makePostRequest(t, "verifications", request)
+	var response verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &response)
+
+	assert.True(response.WithLexicalGroups)
+	require.Len(t, response.Names, 2)
+	solanum := response.Names[0]
+	assert.NotNil(solanum.BestResult)
+	require.NotEmpty(t, solanum.LexicalGroups)
+	lg := solanum.LexicalGroups[0]
+	assert.NotEmpty(lg.ID)
+	assert.Contains(lg.LexicalVariants, lg.Name)
+	assert.Contains(lg.NomCodes, "ICN")
+
+	resp = makePostRequest(t, "verifications", request)
+	var response2 verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &response2)
+	assert.Equal(solanum.LexicalGroups, response2.Names[0].LexicalGroups)
+
+	resp = makeGetRequest(t, "verifications/Bubo%20bubo?lexical_groups=true")
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	require.Len(t, out.Names, 1)
+	assert.NotEmpty(out.Names[0].LexicalGroups)
+}
+
+func TestConflicts(t *testing.T) {
+	assert := assert.New(t)
+	var res conflict.Output
+	resp := makeGetRequest(t, "conflicts/Bubo%20bubo")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	assert.Equal("Bubo bubo", res.Input)
+	assert.Equal("Bubo bubo", res.MatchedName)
+	assert.Greater(res.DataSourcesNum, 1)
+	for _, v := range res.Conflicts {
+		assert.Greater(len(v.Sides), 1)
+		for _, s := range v.Sides {
+			assert.Len(s.DataSourceTitles, len(s.DataSources))
+		}
+	}
+
+	resp = makeGetRequest(t, "conflicts/Bubo%20bubo?data_sources=1")
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	assert.Equal(1, res.DataSourcesNum)
+	assert.True(res.IsEmpty())
+
+	request := verif.Input{
+		Input:         vlib.Input{NameStrings: []string{"Bubo bubo"}},
+		WithConflicts: true,
+	}
+	resp = makePostRequest(t, "verifications", request)
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.True(out.WithConflicts)
+	require.Len(t, out.Names, 1)
+	require.NotNil(t, out.Names[0].Conflicts)
+	assert.Equal(res.MatchedName, out.Names[0].BestResult.MatchedName)
+}
+
+func TestConsensus(t *testing.T) {
+	assert := assert.New(t)
+	resp := makeGetRequest(t, "verifications/Bubo%20bubo?consensus=true")
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.True(out.WithConsensus)
+	require.Len(t, out.Names, 1)
+	cons := out.Names[0].Consensus
+	require.NotNil(t, cons)
+	assert.Equal("Bubo bubo", cons.CurrentCanonical)
+	assert.Equal("Strigidae", cons.Family)
+	assert.Equal("Strigiformes", cons.Order)
+	assert.Equal("Aves", cons.Class)
+	assert.Greater(cons.Support, 0.5)
+	assert.Greater(cons.AcceptedShare, 0.5)
+	assert.Greater(cons.DataSourcesNum, 1)
+
+	var res conflict.Output
+	resp = makeGetRequest(t, "conflicts/Bubo%20bubo?consensus=true")
+	decodeJSONResponse(t, readResponseBody(t, resp), &res)
+	require.NotNil(t, res.Consensus)
+	assert.Equal(*cons, *res.Consensus)
+
+	resp = makeGetRequest(t,
+		"verifications/Pica%20pica|Bubo%20bubo|Nothing%20here?sort=consensus")
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.Equal(verif.SortConsensus, out.Sort)
+	require.Len(t, out.Names, 3)
+	assert.Nil(out.Names[2].Consensus)
+	assert.GreaterOrEqual(out.Names[0].Consensus.Support,
+		out.Names[1].Consensus.Support)
+
+	resp = makeGetRequest(t, "verifications/Bubo%20bubo?sort=bad")
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestStatsDataSource(t *testing.T) {
+	assert := assert.New(t)
+	names := "Bubo+bubo|Strix+aluco|Tyto+alba|Pica+pica"
+	resp := makeGetRequest(t, "verifications/"+names+"?stats=true")
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.Equal(1, out.StatsDataSource)
+	assert.Equal(4, out.StatsNamesNum)
+	assert.Equal("Strigiformes", out.MainTaxon)
+	require.NotEmpty(t, out.Ranks)
+	assert.Equal("kingdom", out.Ranks[0].Rank)
+	assert.Equal("Animalia", out.Ranks[0].Taxa[0].Name)
+	require.Len(t, out.Outliers, 1)
+	assert.Equal("Pica pica", out.Outliers[0].Name)
+
+	resp = makeGetRequest(t, "verifications/"+names+
+		"?stats=true&data_sources=11&stats_data_source=11")
+	out = verif.Output{}
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.Equal(11, out.StatsDataSource)
+	assert.Equal(4, out.StatsNamesNum)
+	assert.Equal("Strigiformes", out.MainTaxon)
+	assert.NotEmpty(out.Ranks)
+
+	resp = makeGetRequest(t, "verifications/"+names+
+		"?stats=true&stats_data_source=99999")
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestContextRanking(t *testing.T) {
+	assert := assert.New(t)
+	names := "Bubo+bubo|Strix+aluco|Tyto+alba|Athene+noctua"
+	resp := makeGetRequest(t, "verifications/"+names+"?context_ranking=true")
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.True(out.WithContextRanking)
+	assert.NotEmpty(out.ContextTaxon)
+	require.Len(t, out.Names, 4)
+	for _, v := range out.Names {
+		require.NotNil(t, v.BestResult)
+		assert.False(v.RankedByContext)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	assert := assert.New(t)
+	request := verif.Input{
+		Input: vlib.Input{
+			NameStrings: []string{"Simulidae", "Bubo bubo"},
+			DataSources: []int{3},
+		},
+		WithSuggestions: true,
+		SuggestionsNum:  3,
+	}
+	resp := makePostRequest(t, "verifications", request)
+	var out verif.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &out)
+	assert.True(out.WithSuggestions)
+	require.Len(t, out.Names, 2)
+
+	simuli := out.Names[0]
+	assert.Equal(vlib.NoMatch, simuli.MatchType)
+	require.NotEmpty(t, simuli.Suggestions)
+	assert.LessOrEqual(len(simuli.Suggestions), 3)
+	sg := simuli.Suggestions[0]
+	assert.Equal("Simuliidae", sg.Name)
+	assert.Equal(1, sg.EditDistance)
+	assert.False(sg.Verified)
+
+	assert.Empty(out.Names[1].Suggestions)
+}
@@ -0,0 +1,412 @@
+package rest
+
+import (
+	"net/http"
+	"slices"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	"github.com/gnames/gnlib/ent/gnvers"
+	"github.com/gnames/gnlib/ent/reconciler"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnquery/ent/search"
+	"github.com/labstack/echo/v4"
+)
+
+// route describes an endpoint of the API. The same table is used to
+// register routes and to generate the OpenAPI specification, so the
+// specification always describes the registered routes.
+type route struct {
+	method  string
+	path    string
+	handler echo.HandlerFunc
+	mw      []echo.MiddlewareFunc
+
+	// tag groups endpoints in the documentation.
+	tag string
+
+	// summary is a short description of the endpoint.
+	summary string
+
+	// query are query parameters of the endpoint. Path parameters are
+	// taken from the path.
+	query []param
+
+	// body is the name of a JSON schema of a request body.
+	body string
+
+	// resp is a zero value of the type of a response body. The schema of
+	// the response is generated from its type. Strings are plain text.
+	resp any
+
+	// deprecated marks endpoints that are kept for backward compatibility.
+	deprecated bool
+}
+
+// param describes a query parameter.
+type param struct {
+	name string
+	typ  string
+	desc string
+}
+
+// limiters keep middlewares that limit requests to endpoints.
+type limiters struct {
+	verify, search, reconcile []echo.MiddlewareFunc
+}
+
+// formatParam selects the format of verification and search results.
+var formatParam = param{
+	"format", "string", "json, csv, tsv or xml, overrides the Accept header",
+}
+
+// verifyFormatParam selects the format of verification results.
+var verifyFormatParam = param{
+	"format", "string",
+	"json, csv, tsv, xml or dwca (Darwin Core Archive), overrides the Accept header",
+}
+
+// publicRoutes returns endpoints of the API that are available without
+// the admin token.
+func publicRoutes(gn gnames.GNames, lims limiters, doc *apiDoc) []route {
+	get, post := http.MethodGet, http.MethodPost
+	// v1 endpoints keep returning JSON for unsupported media types.
+	flatMW := []echo.MiddlewareFunc{negotiation(false, flatFormats...)}
+	verifyMW := slices.Concat(lims.verify,
+		[]echo.MiddlewareFunc{negotiation(false, verifyFormats...)})
+	searchMW := slices.Concat(lims.search, flatMW)
+	verifyQuery := []param{
+		{"data_sources", "string", "pipe-separated IDs, UUIDs or short titles of data-sources"},
+		{"all_matches", "boolean", "return all matched records"},
+		{"capitalize", "boolean", "capitalize the first letter of names"},
+		{"species_group", "boolean", "include species groups into matching"},
+		{"fuzzy_relaxed", "boolean", "relax fuzzy matching rules"},
+		{"fuzzy_uninomial", "boolean", "allow fuzzy matching of uninomials"},
+		{"stats", "boolean", "return statistics of the main taxon"},
+		{"lexical_groups", "boolean", "group all matched results into lexical groups"},
+		{"conflicts", "boolean", "report disagreements of curated data-sources"},
+		{"consensus", "boolean", "add consensus of curated data-sources"},
+		{"main_taxon_threshold", "number", "threshold for the main taxon"},
+		{"stats_data_source", "integer", "ID of a data-source with a classification for stats"},
+		{"context_ranking", "boolean", "prefer results from the main taxon of names"},
+		{"suggestions", "boolean", "suggest low-confidence candidates for names without matches"},
+		{"suggestions_num", "integer", "maximum number of suggestions for a name"},
+		{"sort", "string", "`consensus` orders names by support of their consensus"},
+		{"vernaculars", "string", "pipe-separated languages of vernacular names"},
+		{"vernacular_countries", "string", "pipe-separated country codes of vernacular names"},
+		verifyFormatParam,
+	}
+	return []route{
+		{method: get, path: "/", handler: info, tag: "info",
+			resp:    "",
+			summary: "Information about the API"},
+		{method: get, path: "/api", handler: info, tag: "info",
+			resp:    "",
+			summary: "Information about the API"},
+		{method: get, path: "/api/", handler: info, tag: "info",
+			resp:    "",
+			summary: "Information about the API"},
+		{method: get, path: "/api/v1", handler: info, tag: "info",
+			resp:    "",
+			summary: "Information about the API"},
+		{method: get, path: apiPath, handler: info, tag: "info",
+			resp:    "",
+			summary: "Information about the API"},
+		{method: get, path: "/healthz", handler: healthz, tag: "health",
+			resp:    map[string]string{},
+			summary: "Liveness of the service"},
+		{method: get, path: "/readyz", handler: readyz(gn), tag: "health",
+			resp:    admin.Readiness{},
+			summary: "Readiness of the service and its dependencies"},
+		{method: get, path: apiPath + "ping", handler: ping, tag: "health",
+			resp:    "",
+			summary: "Returns 'pong'"},
+		{method: get, path: apiPath + "version", handler: ver(gn), tag: "info",
+			resp:    gnvers.Version{},
+			summary: "Version of the service"},
+		{method: get, path: apiPath + "openapi.json", handler: doc.openAPIGET,
+			tag: "info", summary: "OpenAPI specification of the API"},
+		{method: get, path: apiPath + "docs", handler: docsGET, tag: "info",
+			summary: "Documentation of the API"},
+		{method: get, path: apiPath + "data_sources", handler: dataSources(gn),
+			resp: []vlib.DataSource{},
+			tag:  "data-sources", summary: "Metadata of data-sources",
+			query: []param{
+				{"filter", "string", "text in titles, descriptions or citations"},
+				{"curation", "string", "pipe-separated curation levels"},
+				{"has_taxon_data", "boolean", "presence of taxonomic data"},
+			}},
+		{method: get, path: apiPath + "data_sources/:id",
+			handler: oneDataSource(gn), tag: "data-sources",
+			resp:    vlib.DataSource{},
+			summary: "Metadata of a data-source by its ID, UUID or short title"},
+		{method: get, path: apiPath + "data_sources/:id/stats",
+			handler: dataSourceStats(gn), mw: lims.search, tag: "data-sources",
+			resp:    dsrc.Stats{},
+			summary: "Statistics of a data-source"},
+		{method: get, path: apiPath + "name_strings",
+			handler: nameInfoGET(gn), tag: "name-strings",
+			resp:    "",
+			summary: "Description of name-strings endpoint"},
+		{method: get, path: apiPath + "name_strings/",
+			handler: nameInfoGET(gn), tag: "name-strings",
+			resp:    "",
+			summary: "Description of name-strings endpoint"},
+		{method: get, path: apiPath + "name_strings/:id",
+			handler: nameGET(gn), mw: flatMW, tag: "name-strings",
+			resp:    vlib.NameStringOutput{},
+			summary: "Name-string by its UUID or spelling",
+			query: []param{
+				{"data_sources", "string", "comma-separated IDs of data-sources"},
+				{"all_matches", "boolean", "return all matched records"},
+				formatParam,
+			}},
+		{method: post, path: apiPath + "name_strings",
+			handler: nameStringsPOST(gn), mw: slices.Concat(lims.verify, flatMW),
+			resp: verif.NameStringsOutput{},
+			tag:  "name-strings", summary: "Many name-strings by UUIDs or spellings",
+			body: "NameStringsInput", query: []param{formatParam}},
+		{method: get, path: apiPath + "canonicals/:id",
+			handler: canonicalGET(gn), mw: lims.search, tag: "name-strings",
+			resp:    canonical.Output{},
+			summary: "Name-strings that share a canonical form, by its UUID or a name",
+			query: []param{
+				{"type", "string", "simple (default), full or stem canonical form"},
+				{"data_sources", "string", "comma-separated IDs of data-sources"},
+				{"limit", "integer", "number of records, 1000 by default, 5000 at most"},
+				{"offset", "integer", "number of records to skip, see `meta.nextOffset`"},
+			}},
+		{method: get, path: apiPath + "conflicts/:name",
+			handler: conflictsGET(gn), mw: lims.verify, tag: "verification",
+			resp:    conflict.Output{},
+			summary: "Disagreements of curated data-sources about a name",
+			query: []param{
+				{"data_sources", "string", "comma-separated IDs of data-sources"},
+				{"consensus", "boolean", "add consensus of curated data-sources"},
+			}},
+		{method: get, path: apiPath + "records/:dataSourceID/:recordID",
+			handler: recordGET(gn), mw: lims.verify, tag: "records",
+			resp:    record.Output{},
+			summary: "Names by an identifier of a data-source record",
+			query: []param{
+				{"id_type", "string", "record_id (default), local_id or global_id"},
+				{"vernaculars", "string", "pipe-separated languages of vernacular names"},
+			}},
+		{method: post, path: apiPath + "records", handler: recordsPOST(gn),
+			resp: record.Output{},
+			mw:   lims.verify, tag: "records",
+			summary: "Names by identifiers of data-source records",
+			body:    "RecordsInput"},
+		{method: post, path: apiPath + "verifications",
+			handler: verificationPOST(gn), mw: verifyMW, tag: "verification",
+			resp:    verif.Output{},
+			summary: "Same as POST verify", body: "VerificationInput",
+			query: []param{verifyFormatParam}, deprecated: true},
+		{method: get, path: apiPath + "verifications/:names",
+			handler: verificationGET(gn), mw: verifyMW, tag: "verification",
+			resp:    verif.Output{},
+			summary: "Same as GET verify", query: verifyQuery, deprecated: true},
+		{method: post, path: apiPath + "verify", handler: verificationPOST(gn),
+			resp: verif.Output{},
+			mw:   verifyMW, tag: "verification",
+			summary: "Verification of name-strings", body: "VerificationInput",
+			query: []param{verifyFormatParam}},
+		{method: get, path: apiPath + "verify/:names",
+			handler: verificationGET(gn), mw: verifyMW, tag: "verification",
+			resp:    verif.Output{},
+			summary: "Verification of pipe-separated name-strings",
+			query:   verifyQuery},
+		{method: post, path: apiPath + "search", handler: searchPOST(gn),
+			resp: search.Output{},
+			mw:   searchMW, tag: "search",
+			summary: "Faceted search of scientific names", body: "SearchInput",
+			query: []param{formatParam}},
+		{method: get, path: apiPath + "search/:query", handler: searchGET(gn),
+			resp: search.Output{},
+			mw:   searchMW, tag: "search",
+			summary: "Faceted search of scientific names",
+			query: []param{
+				{"max_edit_dist", "integer", "edit distance for epithets and authors"},
+				formatParam,
+			}},
+		{method: get, path: apiPath + "vernaculars/search/:query",
+			handler: vernSearchGET(gn), mw: lims.search, tag: "search",
+			resp:    vern.SearchOutput{},
+			summary: "Search of scientific names by vernacular names",
+			query: []param{
+				{"languages", "string", "pipe-separated languages"},
+				{"prefix", "boolean", "match beginnings of vernacular names"},
+			}},
+		{method: get, path: apiPath + "reconcile", handler: reconcileGET(gn),
+			mw: lims.reconcile, tag: "reconciliation",
+			summary: "Reconciliation Service API manifest, queries or extensions",
+			query: []param{
+				{"queries", "string", "JSON with reconciliation queries"},
+				{"extend", "string", "JSON with an extension query"},
+			}},
+		{method: post, path: apiPath + "reconcile", handler: reconcilePOST(gn),
+			resp: reconciler.Output{},
+			mw:   lims.reconcile, tag: "reconciliation",
+			summary: "Reconciliation queries or extensions (form data)",
+			body:    "ReconcileForm"},
+		{method: get, path: apiPath + "reconcile/properties",
+			handler: propertiesGET(gn), tag: "reconciliation",
+			resp:    reconciler.PropertyOutput{},
+			summary: "Properties for reconciliation extensions",
+			query: []param{
+				{"type", "string", "type of reconciled entities"},
+			}},
+	}
+}
+
+// v2Routes returns endpoints of the v2 API.
+func v2Routes(gn gnames.GNames, lims limiters) []route {
+	get, post := http.MethodGet, http.MethodPost
+	mw := []echo.MiddlewareFunc{negotiation(true, jsonOnly...)}
+	flatMW := []echo.MiddlewareFunc{negotiation(true, flatFormats...)}
+	verifyMW := slices.Concat(lims.verify,
+		[]echo.MiddlewareFunc{negotiation(true, verifyFormats...)})
+	page := []param{
+		{"limit", "integer", "number of items on a page"},
+		{"cursor", "string", "offset of the next page from `meta.page.nextCursor`"},
+	}
+	verifyQuery := []param{
+		{"names", "string", "a name-string, repeat the parameter for several names"},
+		{"data_sources", "string", "comma-separated IDs, UUIDs or titles of data-sources"},
+		{"all_matches", "boolean", "return all matched records"},
+		{"capitalize", "boolean", "capitalize the first letter of names"},
+		{"species_group", "boolean", "include species groups into matching"},
+		{"fuzzy_relaxed", "boolean", "relax fuzzy matching rules"},
+		{"fuzzy_uninomial", "boolean", "allow fuzzy matching of uninomials"},
+		{"stats", "boolean", "return statistics of the main taxon"},
+		{"lexical_groups", "boolean", "group all matched results into lexical groups"},
+		{"conflicts", "boolean", "report disagreements of curated data-sources"},
+		{"consensus", "boolean", "add consensus of curated data-sources"},
+		{"main_taxon_threshold", "number", "threshold for the main taxon"},
+		{"stats_data_source", "integer", "ID of a data-source with a classification for stats"},
+		{"context_ranking", "boolean", "prefer results from the main taxon of names"},
+		{"suggestions", "boolean", "suggest low-confidence candidates for names without matches"},
+		{"suggestions_num", "integer", "maximum number of suggestions for a name"},
+		{"sort", "string", "`consensus` orders names by support of their consensus"},
+		{"vernaculars", "string", "comma-separated languages of vernacular names"},
+		{"vernacular_countries", "string", "comma-separated country codes of vernacular names"},
+		verifyFormatParam,
+	}
+	return []route{
+		{method: get, path: "/api/v2", handler: v2InfoGET, mw: mw,
+			resp: envelope.Response[v2Info]{},
+			tag:  "v2", summary: "Information about the API"},
+		{method: get, path: apiPathV2, handler: v2InfoGET, mw: mw,
+			resp: envelope.Response[v2Info]{},
+			tag:  "v2", summary: "Information about the API"},
+		{method: get, path: apiPathV2 + "version", handler: v2VersionGET(gn),
+			resp: envelope.Response[gnvers.Version]{},
+			mw:   mw, tag: "v2", summary: "Version of the service"},
+		{method: get, path: apiPathV2 + "data_sources",
+			handler: v2DataSourcesGET(gn), mw: mw, tag: "v2",
+			resp:    envelope.Response[[]vlib.DataSource]{},
+			summary: "Metadata of data-sources",
+			query: append([]param{
+				{"filter", "string", "text in titles, descriptions or citations"},
+				{"curation", "string", "comma-separated curation levels"},
+				{"has_taxon_data", "boolean", "presence of taxonomic data"},
+			}, page...)},
+		{method: get, path: apiPathV2 + "data_sources/:id",
+			handler: v2DataSourceGET(gn), mw: mw, tag: "v2",
+			resp:    envelope.Response[vlib.DataSource]{},
+			summary: "Metadata of a data-source by its ID, UUID or short title"},
+		{method: get, path: apiPathV2 + "data_sources/:id/stats",
+			handler: v2DataSourceStatsGET(gn), mw: slices.Concat(lims.search, mw),
+			tag:     "v2",
+			resp:    envelope.Response[dsrc.Stats]{},
+			summary: "Statistics of a data-source"},
+		{method: get, path: apiPathV2 + "name_strings/:id",
+			handler: v2NameStringGET(gn), mw: flatMW, tag: "v2",
+			resp:    envelope.Response[vlib.NameStringOutput]{},
+			summary: "Name-string by its UUID or spelling",
+			query: []param{
+				{"data_sources", "string", "comma-separated IDs of data-sources"},
+				{"all_matches", "boolean", "return all matched records"},
+				formatParam,
+			}},
+		{method: get, path: apiPathV2 + "verify", handler: v2VerifyGET(gn),
+			resp: envelope.Response[[]verif.Name]{},
+			mw:   verifyMW, tag: "v2",
+			summary: "Verification of name-strings", query: verifyQuery},
+		{method: post, path: apiPathV2 + "verify", handler: v2VerifyPOST(gn),
+			resp: envelope.Response[[]verif.Name]{},
+			mw:   verifyMW, tag: "v2",
+			summary: "Verification of name-strings", body: "VerificationInput",
+			query: []param{verifyFormatParam}},
+		{method: get, path: apiPathV2 + "search", handler: v2SearchGET(gn),
+			resp: envelope.Response[[]vlib.Name]{},
+			mw:   slices.Concat(lims.search, flatMW), tag: "v2",
+			summary: "Faceted search of scientific names",
+			query: append([]param{
+				{"q", "string", "faceted query"},
+				{"max_edit_dist", "integer", "edit distance for epithets and authors"},
+				formatParam,
+			}, page...)},
+		{method: post, path: apiPathV2 + "search", handler: v2SearchPOST(gn),
+			resp: envelope.Response[[]vlib.Name]{},
+			mw:   slices.Concat(lims.search, flatMW), tag: "v2",
+			summary: "Faceted search of scientific names", body: "SearchInput",
+			query: append([]param{formatParam}, page...)},
+		{method: get, path: apiPathV2 + "vernaculars/search",
+			handler: v2VernSearchGET(gn), mw: slices.Concat(lims.search, mw),
+			resp: envelope.Response[[]vern.SearchResult]{},
+			tag:  "v2", summary: "Search of scientific names by vernacular names",
+			query: append([]param{
+				{"q", "string", "vernacular name"},
+				{"languages", "string", "comma-separated languages"},
+				{"prefix", "boolean", "match beginnings of vernacular names"},
+			}, page...)},
+	}
+}
+
+// adminRoutes returns endpoints of the admin API. Their paths are
+// relative to the admin group.
+func adminRoutes(gn gnames.GNames) []route {
+	get, post := http.MethodGet, http.MethodPost
+	return []route{
+		{method: get, path: "/status", handler: adminStatusGET(gn), tag: "admin",
+			resp:    admin.Status{},
+			summary: "Caches of the matcher, database pool and cached results"},
+		{method: get, path: "/config", handler: adminConfigGET(gn), tag: "admin",
+			resp:    config.Config{},
+			summary: "Configuration without secrets"},
+		{method: post, path: "/matcher/rebuild", handler: rebuildMatcherPOST(gn),
+			resp: []admin.CacheInfo{},
+			tag:  "admin", summary: "Rebuild caches of the embedded matcher"},
+		{method: post, path: "/data_sources/stats/flush",
+			handler: flushDataSourceStatsPOST(gn), tag: "admin",
+			resp:    map[string]int{},
+			summary: "Remove cached statistics of data-sources"},
+		{method: post, path: "/data_sources/reload",
+			handler: reloadDataSourcesPOST(gn), tag: "admin",
+			resp:    dsrc.Changes{},
+			summary: "Reload metadata of data-sources"},
+		{method: post, path: "/data_sources/:id/stats",
+			handler: refreshDataSourceStatsPOST(gn), tag: "admin",
+			resp:    dsrc.Stats{},
+			summary: "Recompute cached statistics of a data-source"},
+	}
+}
+
+// addRoutes registers routes at the echo instance or at a group.
+func addRoutes(
+	add func(string, string, echo.HandlerFunc, ...echo.MiddlewareFunc) *echo.Route,
+	rs []route,
+) {
+	for _, r := range rs {
+		add(r.method, r.path, r.handler, r.mw...)
+	}
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+	textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+	timeType      = reflect.TypeFor[time.Time]()
+)
+
+// schemaNameChars finds characters that are not allowed in names of
+// component schemas.
+var schemaNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// schemaOf returns a JSON schema of values of a Go type. Named structs are
+// added to components of the specification and referenced, so recursive
+// types are possible. Structs of generic types are inlined.
+func (doc *openAPI) schemaOf(t reflect.Type) schema {
+	if t.Kind() == reflect.Pointer {
+		return doc.schemaOf(t.Elem())
+	}
+	if t == timeType {
+		return schema{Type: "string", Format: "date-time"}
+	}
+	if isMarshaler(t) {
+		// custom JSON of enumerations, UUIDs and so on.
+		if t.Kind() == reflect.Struct {
+			return schema{}
+		}
+		return schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64:
+		return schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{Type: "number"}
+	case reflect.String:
+		return schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return schema{Type: "string", Format: "byte"}
+		}
+		items := doc.schemaOf(t.Elem())
+		return schema{Type: "array", Items: &items}
+	case reflect.Map:
+		val := doc.schemaOf(t.Elem())
+		return schema{Type: "object", AdditionalProperties: &val}
+	case reflect.Struct:
+		name := schemaName(t)
+		if name == "" {
+			return doc.structSchema(t)
+		}
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			// the placeholder stops the recursion of recursive types.
+			doc.Components.Schemas[name] = schema{}
+			doc.Components.Schemas[name] = doc.structSchema(t)
+		}
+		return schema{Ref: "#/components/schemas/" + name}
+	}
+	// interfaces can keep any value.
+	return schema{}
+}
+
+// structSchema describes fields of a struct the way encoding/json
+// serializes them. Fields without `omitempty` are always present.
+func (doc *openAPI) structSchema(t reflect.Type) schema {
+	res := schema{Type: "object", Properties: make(map[string]schema)}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// fields of embedded structs are promoted.
+			emb := doc.structSchema(ft)
+			for k, v := range emb.Properties {
+				res.Properties[k] = v
+			}
+			res.Required = append(res.Required, emb.Required...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		res.Properties[name] = doc.schemaOf(f.Type)
+		if !strings.Contains(opts, "omitempty") {
+			res.Required = append(res.Required, name)
+		}
+	}
+	return res
+}
+
+// schemaName creates a name of a component schema from the package and
+// the name of a type, for example `verif.Output`. Anonymous and generic
+// types have no names.
+func schemaName(t reflect.Type) string {
+	if t.Name() == "" || strings.Contains(t.Name(), "[") {
+		return ""
+	}
+	pkg := t.PkgPath()
+	pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+	return schemaNameChars.ReplaceAllString(pkg+"."+t.Name(), "_")
+}
+
+func isMarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t.Implements(jsonMarshaler) || pt.Implements(jsonMarshaler) ||
+		t.Implements(textMarshaler) || pt.Implements(textMarshaler)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gnames/gnfmt"
+	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/gnames/gnquery"
 	"github.com/gnames/gnquery/ent/search"
 	"github.com/stretchr/testify/assert"
@@ -73,3 +74,29 @@ func TestPostSearch(t *testing.T) {
 		assert.Equal(t, len(response.Names) > 0, v.hasResults, v.msg)
 	}
 }
+
+func TestFuzzySearch(t *testing.T) {
+	assert := assert.New(t)
+	query := url.PathEscape("g:Bubo sp:bubbo")
+
+	resp, err := http.Get(searchURL + "/" + query)
+	assert.Nil(err)
+	var response search.Output
+	respBytes, err := io.ReadAll(resp.Body)
+	assert.Nil(err)
+	err = gnfmt.GNjson{}.Decode(respBytes, &response)
+	assert.Nil(err)
+	assert.Equal(0, len(response.Names))
+
+	resp, err = http.Get(searchURL + "/" + query + "?max_edit_dist=1")
+	assert.Nil(err)
+	respBytes, err = io.ReadAll(resp.Body)
+	assert.Nil(err)
+	err = gnfmt.GNjson{}.Decode(respBytes, &response)
+	assert.Nil(err)
+	assert.True(len(response.Names) > 0)
+	best := response.Names[0].BestResult
+	assert.NotNil(best)
+	assert.Equal(1, best.EditDistance)
+	assert.Equal(vlib.Fuzzy, best.MatchType)
+}
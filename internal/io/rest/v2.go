@@ -0,0 +1,431 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gnames "github.com/gnames/gnames/pkg"
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/gnames/gnames/pkg/ent/srch"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnquery"
+	"github.com/gnames/gnuuid"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// apiPathV2 is the prefix of the v2 API. The v2 API follows the same
+// conventions on all endpoints:
+//
+//   - responses are envelopes with `meta`, `data` and `errors`;
+//   - list parameters are repeated (`names=a&names=b`), identifiers and
+//     codes can also be separated by commas (`data_sources=1,11`);
+//   - boolean parameters take `true` or `false`, other values are errors;
+//   - list endpoints are paged with `limit` and `cursor` parameters, the
+//     cursor keeps the offset of the next page, so pages can shift if the
+//     data change between requests;
+//   - the format of a response is negotiated with the `Accept` header.
+const apiPathV2 = "/api/v2/"
+
+// paramError is an error caused by an invalid request parameter.
+type paramError struct {
+	param string
+	msg   string
+}
+
+func (e *paramError) Error() string {
+	if e.param == "" {
+		return e.msg
+	}
+	return fmt.Sprintf("%s: %s", e.param, e.msg)
+}
+
+func newParamError(param, format string, args ...any) error {
+	return &paramError{param: param, msg: fmt.Sprintf(format, args...)}
+}
+
+// v2Info describes the v2 API.
+type v2Info struct {
+	Docs    string `json:"docs"`
+	OpenAPI string `json:"openapi"`
+}
+
+func v2InfoGET(c echo.Context) error {
+	res := v2Info{Docs: apiPath + "docs", OpenAPI: apiPath + "openapi.json"}
+	return c.JSON(http.StatusOK, envelope.New(res, nil))
+}
+
+func v2VersionGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, envelope.New(gn.GetVersion(), nil))
+	}
+}
+
+func v2DataSourcesGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		f := dsrc.Filter{Text: c.QueryParam("filter")}
+		for _, v := range listParam(c, "curation") {
+			cl, ok := dsrc.ParseCuration(v)
+			if !ok {
+				return newParamError("curation", "unknown curation level %q", v)
+			}
+			f.Curation = append(f.Curation, cl)
+		}
+		if c.QueryParam("has_taxon_data") != "" {
+			hasTaxon, err := boolParam(c, "has_taxon_data")
+			if err != nil {
+				return err
+			}
+			f.HasTaxonData = &hasTaxon
+		}
+
+		acc := access.FromContext(c.Request().Context())
+		dss := acc.FilterDataSources(gn.FilterDataSources(f))
+		data, page, err := paginate(c, dss)
+		if err != nil {
+			return err
+		}
+		res := envelope.New(data, nil)
+		res.Meta.Page = page
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+func v2DataSourceGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		id, _ := url.PathUnescape(c.Param("id"))
+		ds, err := visibleDataSource(c, gn, id)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.v2DataSourceGET: %w", err)
+		}
+		return c.JSON(http.StatusOK, envelope.New(ds, nil))
+	}
+}
+
+func v2DataSourceStatsGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		id, _ := url.PathUnescape(c.Param("id"))
+		ds, err := visibleDataSource(c, gn, id)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.v2DataSourceStatsGET: %w", err)
+		}
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		stats, err := gn.DataSourceStats(ctx, ds.ID, false)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.v2DataSourceStatsGET: %w", err)
+		}
+		return c.JSON(http.StatusOK, envelope.New(stats, nil))
+	}
+}
+
+func v2NameStringGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		idStr, _ := url.PathUnescape(c.Param("id"))
+		if _, err := uuid.Parse(idStr); err != nil {
+			idStr = gnuuid.New(idStr).String()
+		}
+		ds, err := intsParam(c, "data_sources")
+		if err != nil {
+			return err
+		}
+		matches, err := boolParam(c, "all_matches")
+		if err != nil {
+			return err
+		}
+		params := vlib.NameStringInput{
+			ID:             idStr,
+			DataSources:    ds,
+			WithAllMatches: matches,
+		}
+
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		name, err := gn.NameByID(ctx, params, false)
+		if errors.Is(err, dsrc.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return fmt.Errorf("rest.v2NameStringGET: %w", err)
+		}
+		var names []vlib.Name
+		if name.Name != nil {
+			names = []vlib.Name{*name.Name}
+		}
+		return respond(c, envelope.New(name, nil), names, matches)
+	}
+}
+
+func v2VerifyGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		inp, err := v2VerifyInput(c)
+		if err != nil {
+			return err
+		}
+		return v2Verify(c, gn, inp)
+	}
+}
+
+func v2VerifyPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		var inp verif.Input
+		if err := c.Bind(&inp); err != nil {
+			return err
+		}
+		return v2Verify(c, gn, inp)
+	}
+}
+
+// v2VerifyInput creates verification input from query parameters.
+func v2VerifyInput(c echo.Context) (verif.Input, error) {
+	var res verif.Input
+	var err error
+	for _, v := range verifFlags(&res) {
+		if *v.val, err = boolParam(c, v.name); err != nil {
+			return res, err
+		}
+	}
+	if s := c.QueryParam("main_taxon_threshold"); s != "" {
+		th, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return res, newParamError("main_taxon_threshold", "must be a number")
+		}
+		res.MainTaxonThreshold = float32(th)
+	}
+	if res.StatsDataSource, err = intParam(c, "stats_data_source"); err != nil {
+		return res, err
+	}
+	if res.SuggestionsNum, err = intParam(c, "suggestions_num"); err != nil {
+		return res, err
+	}
+	res.Sort = c.QueryParam("sort")
+	res.NameStrings = c.QueryParams()["names"]
+	res.DataSources = listParam(c, "data_sources")
+	res.Vernaculars = listParam(c, "vernaculars")
+	res.VernacularCountries = listParam(c, "vernacular_countries")
+	return res, nil
+}
+
+// queryFlag is a boolean query parameter and the field it sets.
+type queryFlag struct {
+	name string
+	val  *bool
+}
+
+// verifFlags returns boolean query parameters of verification.
+func verifFlags(inp *verif.Input) []queryFlag {
+	return []queryFlag{
+		{"all_matches", &inp.WithAllMatches},
+		{"capitalize", &inp.WithCapitalization},
+		{"species_group", &inp.WithSpeciesGroup},
+		{"fuzzy_relaxed", &inp.WithRelaxedFuzzyMatch},
+		{"fuzzy_uninomial", &inp.WithUninomialFuzzyMatch},
+		{"stats", &inp.WithStats},
+		{"lexical_groups", &inp.WithLexicalGroups},
+		{"conflicts", &inp.WithConflicts},
+		{"consensus", &inp.WithConsensus},
+		{"context_ranking", &inp.WithContextRanking},
+		{"suggestions", &inp.WithSuggestions},
+	}
+}
+
+func v2Verify(c echo.Context, gn gnames.GNames, inp verif.Input) error {
+	if len(inp.NameStrings) == 0 {
+		return newParamError("names", "at least one name-string is required")
+	}
+	err := checkNamesNum(len(inp.NameStrings), gn.GetConfig().MaxNamesPerRequest)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getContext(c)
+	defer cancel()
+
+	out, err := gn.VerifyWithOptions(ctx, inp)
+	if errors.Is(err, dsrc.ErrNotFound) {
+		return newParamError("data_sources", "%s", err.Error())
+	}
+	if errors.Is(err, verif.ErrSort) {
+		return newParamError("sort", "must be empty or consensus")
+	}
+	if errors.Is(err, verif.ErrStatsDataSource) {
+		return newParamError("stats_data_source", "%s", err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("rest.v2Verify: %w", err)
+	}
+	res := envelope.New(out.Names, out.Meta)
+	return respondVerif(c, gn, res, out, inp.WithAllMatches)
+}
+
+func v2SearchGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		q := c.QueryParam("q")
+		if q == "" {
+			return newParamError("q", "query is required")
+		}
+		maxEditDist, err := intParam(c, "max_edit_dist")
+		if err != nil {
+			return err
+		}
+		inp := srch.Input{
+			Input:       gnquery.New().Parse(q),
+			MaxEditDist: maxEditDist,
+		}
+		return v2Search(c, gn, inp)
+	}
+}
+
+func v2SearchPOST(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		var inp srch.Input
+		if err := c.Bind(&inp); err != nil {
+			return err
+		}
+		inp.Input = gnquery.New().Process(inp.Input)
+		return v2Search(c, gn, inp)
+	}
+}
+
+func v2Search(c echo.Context, gn gnames.GNames, inp srch.Input) error {
+	if err := inp.Validate(); err != nil {
+		return newParamError("q", "%s", err.Error())
+	}
+	ctx, cancel := getContext(c)
+	defer cancel()
+
+	out := gn.Search(ctx, inp)
+	if out.Error != "" {
+		return fmt.Errorf("rest.v2Search: %s", out.Error)
+	}
+	data, page, err := paginate(c, out.Names)
+	if err != nil {
+		return err
+	}
+	res := envelope.New(data, out.Meta)
+	res.Meta.Page = page
+	return respond(c, res, data, inp.WithAllMatches)
+}
+
+func v2VernSearchGET(gn gnames.GNames) func(echo.Context) error {
+	return func(c echo.Context) error {
+		q := c.QueryParam("q")
+		if q == "" {
+			return newParamError("q", "query is required")
+		}
+		prefix, err := boolParam(c, "prefix")
+		if err != nil {
+			return err
+		}
+		langs := listParam(c, "languages")
+		for i := range langs {
+			langs[i] = strings.ToLower(langs[i])
+		}
+		ctx, cancel := getContext(c)
+		defer cancel()
+
+		inp := vern.SearchInput{Query: q, Languages: langs, WithPrefix: prefix}
+		out := gn.SearchVernaculars(ctx, inp)
+		if out.Error != "" {
+			return fmt.Errorf("rest.v2VernSearchGET: %s", out.Error)
+		}
+		data, page, err := paginate(c, out.Names)
+		if err != nil {
+			return err
+		}
+		res := envelope.New(data, out.SearchMeta)
+		res.Meta.Page = page
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// paginate returns the page of items according to `limit` and `cursor`
+// parameters.
+func paginate[T any](c echo.Context, items []T) ([]T, *envelope.Page, error) {
+	limit, err := intParam(c, "limit")
+	if err != nil {
+		return nil, nil, err
+	}
+	if limit < 0 {
+		return nil, nil, newParamError("limit", "must not be negative")
+	}
+	res, page, err := envelope.Paginate(items, c.QueryParam("cursor"), limit)
+	if err != nil {
+		return nil, nil, newParamError("cursor", "%s", err.Error())
+	}
+	return res, &page, nil
+}
+
+// listParam returns values of a query parameter. Values can be given by
+// repeating the parameter or separated by commas. Empty values are
+// ignored.
+func listParam(c echo.Context, name string) []string {
+	var res []string
+	for _, v := range c.QueryParams()[name] {
+		for s := range strings.SplitSeq(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+	return res
+}
+
+// intsParam returns integer values of a list parameter.
+func intsParam(c echo.Context, name string) ([]int, error) {
+	var res []int
+	for _, v := range listParam(c, name) {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, newParamError(name, "%q is not an integer", v)
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
+
+// boolParam returns the value of a boolean parameter. A missing parameter
+// is false.
+func boolParam(c echo.Context, name string) (bool, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return false, nil
+	}
+	res, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, newParamError(name, "must be true or false")
+	}
+	return res, nil
+}
+
+// intParam returns the value of an integer parameter. A missing parameter
+// is zero.
+func intParam(c echo.Context, name string) (int, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return 0, nil
+	}
+	res, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, newParamError(name, "must be an integer")
+	}
+	return res, nil
+}
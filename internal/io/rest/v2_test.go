@@ -0,0 +1,154 @@
+package rest_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	"github.com/gnames/gnfmt"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var restURLV2 = getConfig().GnamesHostURL + "/api/v2/"
+
+// getV2 sends a GET request to the v2 API and decodes the envelope.
+func getV2[T any](t *testing.T, endpoint string) (int, envelope.Response[T]) {
+	t.Helper()
+
+	resp, err := http.Get(restURLV2 + endpoint)
+	require.NoError(t, err)
+	body := readResponseBody(t, resp)
+
+	var res envelope.Response[T]
+	decodeJSONResponse(t, body, &res)
+	return resp.StatusCode, res
+}
+
+func TestV2Verify(t *testing.T) {
+	assert := assert.New(t)
+	q := url.Values{
+		"names":        {"Homo sapiens", "Pomatomus saltatrix (Linnaeus, 1766)"},
+		"data_sources": {"1,11"},
+		"all_matches":  {"true"},
+	}
+	status, res := getV2[[]verif.Name](t, "verify?"+q.Encode())
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("v2", res.Meta.APIVersion)
+	assert.Empty(res.Errors)
+	require.Len(t, res.Data, 2)
+	assert.Equal("Homo sapiens", res.Data[0].Name.Name)
+	assert.Equal(vlib.Exact, res.Data[1].MatchType)
+	for _, v := range res.Data[0].Results {
+		assert.Contains([]int{1, 11}, v.DataSourceID)
+	}
+
+	resp := makeV2Post(t, "verify", verif.Input{
+		Input: vlib.Input{NameStrings: []string{"Bubo bubo"}},
+	})
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	var post envelope.Response[[]verif.Name]
+	decodeJSONResponse(t, readResponseBody(t, resp), &post)
+	require.Len(t, post.Data, 1)
+	assert.Equal(vlib.Exact, post.Data[0].MatchType)
+}
+
+func TestV2Errors(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		endpoint string
+		status   int
+		code     string
+		param    string
+	}{
+		{"verify", 400, "invalid_parameter", "names"},
+		{"verify?names=Bubo&all_matches=1x", 400, "invalid_parameter", "all_matches"},
+		{"verify?names=Bubo&data_sources=nothing", 400, "invalid_parameter", "data_sources"},
+		{"data_sources?cursor=bad", 400, "invalid_parameter", "cursor"},
+		{"search?q=g:Bubo", 400, "invalid_parameter", "q"},
+		{"data_sources?limit=-1", 400, "invalid_parameter", "limit"},
+		{"data_sources/100000", 404, "not_found", ""},
+		{"nothing", 404, "not_found", ""},
+	}
+	for _, v := range tests {
+		status, res := getV2[any](t, v.endpoint)
+		assert.Equal(v.status, status, v.endpoint)
+		require.Len(t, res.Errors, 1, v.endpoint)
+		assert.Equal(v.code, res.Errors[0].Code, v.endpoint)
+		assert.Equal(v.param, res.Errors[0].Parameter, v.endpoint)
+		assert.Nil(res.Data, v.endpoint)
+	}
+}
+
+func TestV2Paging(t *testing.T) {
+	assert := assert.New(t)
+	var all []*vlib.DataSource
+	endpoint := "data_sources?limit=20"
+	for {
+		status, res := getV2[[]*vlib.DataSource](t, endpoint)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, res.Meta.Page)
+		assert.Equal(20, res.Meta.Page.Limit)
+		assert.LessOrEqual(len(res.Data), 20)
+		all = append(all, res.Data...)
+		if res.Meta.Page.NextCursor == "" {
+			assert.Equal(res.Meta.Page.Total, len(all))
+			break
+		}
+		endpoint = "data_sources?limit=20&cursor=" + res.Meta.Page.NextCursor
+	}
+	assert.Greater(len(all), 50)
+	assert.Equal(1, all[0].ID)
+
+	status, res := getV2[[]vern.SearchResult](t,
+		"vernaculars/search?q=snowy+owl&languages=eng&limit=1")
+	assert.Equal(http.StatusOK, status)
+	assert.LessOrEqual(len(res.Data), 1)
+}
+
+// TestV1Compat pins v1 conventions that differ from v2: pipe-separated
+// names and data-sources in verification, comma-separated data-sources
+// in name-strings, duplicate verification routes and plain errors.
+func TestV1Compat(t *testing.T) {
+	assert := assert.New(t)
+	verifs := getVerificationRequest(t, "Homo+sapiens|Bubo+bubo?data_sources=1|11")
+	resp := makeGetRequest(t, "verify/Homo+sapiens|Bubo+bubo?data_sources=1|11")
+	var verify vlib.Output
+	decodeJSONResponse(t, readResponseBody(t, resp), &verify)
+	require.Len(t, verifs.Names, 2)
+	require.Len(t, verify.Names, 2)
+	assert.Equal(verifs.DataSources, verify.DataSources)
+	assert.Equal([]int{1, 11}, verify.DataSources)
+	for i := range verify.Names {
+		assert.Equal(verifs.Names[i].MatchType, verify.Names[i].MatchType)
+	}
+
+	resp = makeGetRequest(t,
+		"name_strings/0eeccd70-eaf2-5c51-ad8b-46cfb3db1645?data_sources=1,11")
+	var name vlib.NameStringOutput
+	decodeJSONResponse(t, readResponseBody(t, resp), &name)
+	assert.Equal([]int{1, 11}, name.DataSources)
+
+	resp = makeGetRequest(t, "nothing")
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+	assert.JSONEq(`{"message":"Not Found"}`, string(readResponseBody(t, resp)))
+}
+
+// makeV2Post sends a POST request with JSON payload to the v2 API.
+func makeV2Post(t *testing.T, endpoint string, payload any) *http.Response {
+	t.Helper()
+
+	reqBytes, err := gnfmt.GNjson{}.Encode(payload)
+	require.NoError(t, err)
+
+	resp, err := http.Post(
+		restURLV2+endpoint, "application/json", bytes.NewReader(reqBytes),
+	)
+	require.NoError(t, err)
+	return resp
+}
@@ -0,0 +1,118 @@
+package rest_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVernacularSearch(t *testing.T) {
+	tests := []struct {
+		msg, query, params string
+		hasResults         bool
+		name               string
+	}{
+		{"snowy owl", "snowy owl", "", true, "Bubo scandiacus"},
+		{"case and accents", "Snowy-Owl", "?languages=eng", true, "Bubo scandiacus"},
+		{"prefix", "snowy o", "?prefix=true", true, "Bubo scandiacus"},
+		{"no prefix", "snowy o", "", false, ""},
+		{"wrong language", "snowy owl", "?languages=rus", false, ""},
+	}
+
+	for _, v := range tests {
+		resp := makeGetRequest(t,
+			"vernaculars/search/"+url.PathEscape(v.query)+v.params)
+		body := readResponseBody(t, resp)
+		var res vern.SearchOutput
+		decodeJSONResponse(t, body, &res)
+		assert.Equal(t, v.hasResults, len(res.Names) > 0, v.msg)
+		if !v.hasResults {
+			continue
+		}
+		var found bool
+		for _, n := range res.Names {
+			if strings.HasPrefix(n.Name, v.name) {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, v.msg)
+	}
+}
+
+// TestVernacularMerge checks that vernacular names from different
+// data-sources are merged and that each language has a preferred name.
+func TestVernacularMerge(t *testing.T) {
+	tests := []struct {
+		msg       string
+		countries []string
+		hasVerns  bool
+	}{
+		{"no countries", nil, true},
+		{"unknown country", []string{"ZZ"}, false},
+	}
+
+	for _, v := range tests {
+		inp := verif.Input{
+			Input: vlib.Input{
+				NameStrings: []string{"Egretta thula"},
+				Vernaculars: []string{"eng"},
+			},
+			VernacularCountries: v.countries,
+		}
+		resp := makePostRequest(t, "verifications", inp)
+		body := readResponseBody(t, resp)
+		var res verif.Output
+		decodeJSONResponse(t, body, &res)
+		require.Len(t, res.Names, 1, v.msg)
+		verns := res.Names[0].Vernaculars
+		assert.Equal(t, v.hasVerns, len(verns) > 0, v.msg)
+		if !v.hasVerns {
+			continue
+		}
+
+		var preferred int
+		seen := make(map[string]struct{})
+		for _, vrn := range verns {
+			assert.Equal(t, "eng", vrn.LanguageCode, v.msg)
+			assert.Equal(t, len(vrn.DataSourcesIDs), vrn.DataSourcesNum, v.msg)
+			norm := vern.Normalize(vrn.Name)
+			_, ok := seen[norm]
+			assert.False(t, ok, v.msg)
+			seen[norm] = struct{}{}
+			if vrn.Preferred {
+				preferred++
+			}
+		}
+		assert.Equal(t, 1, preferred, v.msg)
+		assert.True(t, verns[0].Preferred, v.msg)
+	}
+}
+
+// TestVernacularManyNames checks that names are not truncated when
+// vernacular names are requested.
+func TestVernacularManyNames(t *testing.T) {
+	names := make([]string, 120)
+	for i := range names {
+		names[i] = "Egretta thula"
+	}
+	names[len(names)-1] = "Bubo scandiacus"
+	inp := vlib.Input{NameStrings: names, Vernaculars: []string{"eng"}}
+	resp := makePostRequest(t, "verifications", inp)
+	body := readResponseBody(t, resp)
+	var res verif.Output
+	decodeJSONResponse(t, body, &res)
+
+	require.Len(t, res.Names, len(names))
+	assert.Equal(t, len(names), res.NamesNumber)
+	assert.Positive(t, res.VernacularChunksNum)
+	last := res.Names[len(names)-1]
+	assert.Equal(t, "Bubo scandiacus", last.Name.Name)
+	assert.NotEmpty(t, last.Vernaculars)
+}
@@ -2,44 +2,39 @@ package srchio
 
 import (
 	"context"
-	"errors"
+	"log/slog"
 
 	"github.com/gnames/gnames/pkg/config"
 	"github.com/gnames/gnames/pkg/ent/pg"
 	"github.com/gnames/gnames/pkg/ent/srch"
 	"github.com/gnames/gnames/pkg/ent/verif"
-	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/gnames/gnparser/ent/parsed"
-	"github.com/gnames/gnquery/ent/search"
 )
 
 type srchio struct {
 	db pg.PG
-	search.Input
-	dsm map[int]*vlib.DataSource
+	srch.Input
 }
 
 func New(cnf config.Config, db pg.PG) (srch.Searcher, error) {
-	res := srchio{
-		db:  db,
-		dsm: db.DataSourcesMap(),
-	}
+	res := srchio{db: db}
 	return &res, nil
 }
 
-// AdvancedSearch takes a search.Input, perfomes an advanced search
+// AdvancedSearch takes a srch.Input, perfomes an advanced search
 // and returns a map of MatchRecords.
 func (s *srchio) AdvancedSearch(
 	ctx context.Context,
-	input search.Input,
+	input srch.Input,
 ) (map[string]*verif.MatchRecord, error) {
 	var err error
 	res := make(map[string]*verif.MatchRecord)
+	input.MaxEditDist = maxEditDist(input.MaxEditDist)
 	s.Input = input
-	spWordIDs, spWord := s.spInput()
-	if spWordIDs == nil {
-		return res, errors.New("cannot run search without species epithet data")
+	if err = input.Validate(); err != nil {
+		return res, err
 	}
+	spWordIDs, spWord := s.spInput()
 
 	res, err = s.db.SearchRecordsMap(ctx, s.Input, spWordIDs, spWord)
 	if err != nil {
@@ -49,6 +44,22 @@ func (s *srchio) AdvancedSearch(
 
 }
 
+// maxEditDist keeps edit distance tolerance within allowed limits.
+func maxEditDist(i int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > srch.MaxEditDistLimit {
+		slog.Warn(
+			"MaxEditDist for search is too high, using allowed max",
+			slog.Int("entered_val", i),
+			slog.Int("max_val", srch.MaxEditDistLimit),
+		)
+		return srch.MaxEditDistLimit
+	}
+	return i
+}
+
 func (s *srchio) spInput() ([]int, string) {
 	if s.SpeciesInfra != "" {
 		return []int{int(parsed.InfraspEpithetType)}, s.SpeciesInfra
@@ -1,27 +1,47 @@
 package verifio
 
 import (
-	"cmp"
 	"context"
+	"fmt"
 	"log/slog"
-	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
 	"github.com/gnames/gnames/pkg/ent/pg"
+	"github.com/gnames/gnames/pkg/ent/record"
 	"github.com/gnames/gnames/pkg/ent/verif"
 	mlib "github.com/gnames/gnlib/ent/matcher"
 	vlib "github.com/gnames/gnlib/ent/verifier"
+	"golang.org/x/sync/singleflight"
 )
 
+// statsTimeout limits the time of computing statistics of a data-source.
+const statsTimeout = 30 * time.Minute
+
 type verifio struct {
-	db  pg.PG
-	dsm map[int]*vlib.DataSource
+	db pg.PG
+	// reg is the registry of data-sources shared with the database layer.
+	reg *dsrc.Registry
+
+	// statsMu protects stats cache.
+	statsMu sync.Mutex
+	// stats is a cache of data-sources statistics.
+	stats map[int]dsrc.Stats
+	// statsGroup makes concurrent requests of the same statistics share
+	// one computation.
+	statsGroup singleflight.Group
 }
 
 func New(cfg config.Config, db pg.PG) (verif.Verifier, error) {
 	res := verifio{
-		db:  db,
-		dsm: db.DataSourcesMap(),
+		db:    db,
+		reg:   db.DataSources(),
+		stats: make(map[int]dsrc.Stats),
 	}
 	return &res, nil
 }
@@ -34,23 +54,118 @@ func (v *verifio) DataSources(ids ...int) []*vlib.DataSource {
 	res := make([]*vlib.DataSource, 0, len(ids))
 	if len(ids) > 0 {
 		for _, i := range ids {
-			if ds, ok := v.dsm[i]; ok {
+			if ds, ok := v.reg.Get(i); ok {
 				res = append(res, ds)
 			} else {
 				slog.Warn("Data source not found", "id", i)
 			}
 		}
-		if len(res) > 0 {
-			return res
-		}
+		return res
+	}
+	return v.reg.List()
+}
+
+// DataSource finds a data-source by its ID, UUID or short title.
+func (v *verifio) DataSource(id string) (*vlib.DataSource, error) {
+	ds, ok := dsrc.Find(v.DataSources(), id)
+	if !ok {
+		return nil, fmt.Errorf("verifio.DataSource: %q: %w", id, dsrc.ErrNotFound)
+	}
+	return ds, nil
+}
+
+// FilterDataSources returns data-sources that satisfy the filter.
+func (v *verifio) FilterDataSources(f dsrc.Filter) []*vlib.DataSource {
+	return f.Apply(v.DataSources())
+}
+
+// DataSourceStats returns cached statistics of a data-source, or computes
+// them if they are not cached yet, or if refresh is true. Concurrent
+// computations of the same statistics are done only once.
+func (v *verifio) DataSourceStats(
+	ctx context.Context,
+	id int,
+	refresh bool,
+) (dsrc.Stats, error) {
+	if _, ok := v.reg.Get(id); !ok {
+		return dsrc.Stats{}, fmt.Errorf("verifio.DataSourceStats: id %d: %w", id, dsrc.ErrNotFound)
 	}
 
-	for _, ds := range v.dsm {
-		res = append(res, ds)
+	v.statsMu.Lock()
+	res, ok := v.stats[id]
+	v.statsMu.Unlock()
+	if ok && !refresh {
+		return res, nil
 	}
-	slices.SortFunc(res, func(a, b *vlib.DataSource) int {
-		return cmp.Compare(a.ID, b.ID)
+
+	// The computation is shared by all waiting requests, so it does not
+	// stop when the request that started it is cancelled.
+	ch := v.statsGroup.DoChan(strconv.Itoa(id), func() (any, error) {
+		sctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statsTimeout)
+		defer cancel()
+		res, err := v.db.DataSourceStats(sctx, id)
+		if err != nil {
+			return res, err
+		}
+		v.statsMu.Lock()
+		v.stats[id] = res
+		v.statsMu.Unlock()
+		return res, nil
 	})
+	select {
+	case <-ctx.Done():
+		return dsrc.Stats{}, fmt.Errorf("verifio.DataSourceStats: %w", ctx.Err())
+	case r := <-ch:
+		if r.Err != nil {
+			return dsrc.Stats{}, fmt.Errorf("verifio.DataSourceStats: %w", r.Err)
+		}
+		return r.Val.(dsrc.Stats), nil
+	}
+}
+
+// ReloadDataSources updates the registry of data-sources from the
+// database. Cached statistics of changed data-sources are removed.
+func (v *verifio) ReloadDataSources(
+	ctx context.Context,
+) (dsrc.Changes, error) {
+	res, err := v.db.ReloadDataSources(ctx)
+	if err != nil {
+		return res, fmt.Errorf("verifio.ReloadDataSources: %w", err)
+	}
+
+	v.statsMu.Lock()
+	for _, ids := range [][]int{res.Updated, res.Removed} {
+		for _, id := range ids {
+			delete(v.stats, id)
+		}
+	}
+	v.statsMu.Unlock()
+	return res, nil
+}
+
+// Ping checks connectivity to the database.
+func (v *verifio) Ping(ctx context.Context) error {
+	return v.db.Ping(ctx)
+}
+
+// PoolStats returns the state of the database connections pool.
+func (v *verifio) PoolStats() admin.PoolStats {
+	return v.db.PoolStats()
+}
+
+// CachedResultsNum returns the number of cached data-sources statistics.
+func (v *verifio) CachedResultsNum() int {
+	v.statsMu.Lock()
+	defer v.statsMu.Unlock()
+	return len(v.stats)
+}
+
+// FlushDataSourceStats removes cached data-sources statistics.
+func (v *verifio) FlushDataSourceStats() int {
+	v.statsMu.Lock()
+	defer v.statsMu.Unlock()
+	res := len(v.stats)
+	v.stats = make(map[int]dsrc.Stats)
 	return res
 }
 
@@ -81,8 +196,48 @@ func (v *verifio) NameByID(inp vlib.NameStringInput) (*verif.MatchRecord, error)
 	return v.db.NameByID(inp)
 }
 
+// NamesByIDs takes name-string UUIDs and returns matched results as a
+// map where keys are UUIDs.
+func (v *verifio) NamesByIDs(
+	ctx context.Context,
+	ids []string,
+	dataSources []int,
+) (map[string]*verif.MatchRecord, error) {
+	return v.db.NamesByIDs(ctx, ids, dataSources)
+}
+
+// RecordsByIDs finds records of a data-source by their identifiers.
+func (v *verifio) RecordsByIDs(
+	ctx context.Context,
+	dataSourceID int,
+	idType record.IDType,
+	ids []string,
+) (map[string][]*vlib.ResultData, error) {
+	return v.db.RecordsByIDs(ctx, dataSourceID, idType, ids)
+}
+
+// CanonicalRecords finds records of all name-strings that share the
+// canonical form.
+func (v *verifio) CanonicalRecords(
+	ctx context.Context,
+	id string,
+	kind canonical.Kind,
+	dataSources []int,
+	limit, offset int,
+) (string, []*vlib.ResultData, error) {
+	return v.db.CanonicalRecords(ctx, id, kind, dataSources, limit, offset)
+}
+
 // NameStringByID takes UUID as an argument and returns back a name-string
 // that corresponds to that UUID.
 func (v *verifio) NameStringByID(id string) (string, error) {
 	return v.db.NameStringByID(id)
 }
+
+// NameStringsByIDs returns name-strings that correspond to UUIDs.
+func (v *verifio) NameStringsByIDs(
+	ctx context.Context,
+	ids []string,
+) (map[string]string, error) {
+	return v.db.NameStringsByIDs(ctx, ids)
+}
@@ -3,12 +3,14 @@ package vernio
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/gnames/gnames/pkg/config"
 	"github.com/gnames/gnames/pkg/ent/pg"
 	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnlib/ent/verifier"
 	vlib "github.com/gnames/gnlib/ent/verifier"
+	"golang.org/x/sync/errgroup"
 )
 
 type vernio struct {
@@ -24,13 +26,22 @@ func New(cfg config.Config, db pg.PG) vern.Vernaculars {
 	return &res
 }
 
-func (v *vernio) AddVernacularNames(langs []string, names []vlib.Name) ([]vlib.Name, error) {
-	ctx := context.Background()
+// vernChunkSize is the maximal number of records that are sent to the
+// database in one transaction.
+const vernChunkSize = 2000
+
+func (v *vernio) AddVernacularNames(
+	ctx context.Context,
+	langs []string,
+	countries []string,
+	names []vlib.Name,
+) (vern.Stats, error) {
+	var res vern.Stats
 	// recordsMap is a map where records are keys and corresponding verifier.ResultData are values.
 	recordsMap := vernacularRecords(names)
 	// did not find any records to search for vernaculars
 	if len(recordsMap) == 0 {
-		return names, nil
+		return res, nil
 	}
 
 	records := make([]vern.Record, len(recordsMap))
@@ -39,20 +50,72 @@ func (v *vernio) AddVernacularNames(langs []string, names []vlib.Name) ([]vlib.N
 		records[count] = k
 		count++
 	}
+	chunks := chunkRecords(records, vernChunkSize)
+	res = vern.Stats{RecordsNum: len(records), ChunksNum: len(chunks)}
 
 	// GetVernaculars should generate exactly the same records as generated by vernacularRecords
-	verns, err := v.db.GetVernaculars(ctx, records, langs)
+	verns, err := v.getVernaculars(ctx, chunks, langs, countries)
 	if err != nil {
-		return names, fmt.Errorf("vernio.AddVernacularNames: failed to get vernaculars: %w", err)
+		return res, fmt.Errorf("vernio.AddVernacularNames: failed to get vernaculars: %w", err)
 	}
 
-	for k, v := range verns {
-		for i := range recordsMap[k] {
-			recordsMap[k][i].Vernaculars = v
+	for _, vm := range verns {
+		for k, v := range vm {
+			for i := range recordsMap[k] {
+				recordsMap[k][i].Vernaculars = v
+			}
 		}
 	}
 
-	return names, nil
+	return res, nil
+}
+
+// getVernaculars runs queries for chunks of records concurrently. Every
+// chunk uses its own transaction with a temporary table of records.
+func (v *vernio) getVernaculars(
+	ctx context.Context,
+	chunks [][]vern.Record,
+	langs []string,
+	countries []string,
+) ([]map[vern.Record][]vlib.Vernacular, error) {
+	res := make([]map[vern.Record][]vlib.Vernacular, len(chunks))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(v.cfg.JobsNum, 1))
+	for i := range chunks {
+		g.Go(func() error {
+			verns, err := v.db.GetVernaculars(ctx, chunks[i], langs, countries)
+			if err != nil {
+				return err
+			}
+			res[i] = verns
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func chunkRecords(recs []vern.Record, size int) [][]vern.Record {
+	res := make([][]vern.Record, 0, (len(recs)+size-1)/size)
+	for chunk := range slices.Chunk(recs, size) {
+		res = append(res, chunk)
+	}
+	return res
+}
+
+// SearchVernaculars finds scientific names of taxa by their vernacular
+// names.
+func (v *vernio) SearchVernaculars(
+	ctx context.Context,
+	inp vern.SearchInput,
+) ([]vern.SearchResult, error) {
+	res, err := v.db.SearchVernaculars(ctx, inp)
+	if err != nil {
+		return nil, fmt.Errorf("vernio.SearchVernaculars: %w", err)
+	}
+	return res, nil
 }
 
 func updateNames(names []vlib.Name, verns []vlib.Vernacular) []vlib.Name {
@@ -191,6 +191,12 @@ table "name_string_indices" {
   index "name_string_ids_idx" {
     columns = [column.data_source_id, column.record_id, column.name_string_id]
   }
+  index "nsi_local_id_idx" {
+    columns = [column.data_source_id, column.local_id]
+  }
+  index "nsi_global_id_idx" {
+    columns = [column.data_source_id, column.global_id]
+  }
 }
 table "name_strings" {
   schema = schema.public
@@ -350,6 +356,12 @@ table "words" {
   index "words_modified" {
     columns = [column.modified]
   }
+  index "words_modified_pattern" {
+    on {
+      column = column.modified
+      ops    = varchar_pattern_ops
+    }
+  }
 }
 schema "public" {
   comment = "standard public schema"
@@ -0,0 +1,18 @@
+-- Normalized vernacular names for search.
+--
+-- gn_vern_norm normalizes a vernacular name the same way as
+-- vern.Normalize does. Arguments of translate must be the same as
+-- the strings returned by vern.TranslateChars (a test checks that).
+-- The expression index makes exact and prefix searches by normalized
+-- names use the index instead of scanning the whole table.
+CREATE OR REPLACE FUNCTION gn_vern_norm(text) RETURNS text
+  LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+  AS $$
+    SELECT trim(regexp_replace(translate(lower($1),
+      '-àáâãäåçèéêëìíîïñòóôõöøùúûüýÿāăąćčďđēėęěğīįıłńňōőřśşšţťūůűųźżžșț',
+      ' aaaaaaceeeeiiiinoooooouuuuyyaaaccddeeeegiiilnnoorsssttuuuuzzzst'),
+      '\s+', ' ', 'g'))
+  $$;
+
+CREATE INDEX IF NOT EXISTS vern_str_norm_idx
+  ON vernacular_strings (gn_vern_norm(name) text_pattern_ops);
@@ -0,0 +1,34 @@
+package gnames
+
+import (
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// restrictRecords removes results from data-sources hidden from a client.
+func restrictRecords(acc access.Access, mrs map[string]*verif.MatchRecord) {
+	if !acc.IsRestricted() {
+		return
+	}
+	for _, mr := range mrs {
+		restrictRecord(acc, mr)
+	}
+}
+
+// restrictRecord removes results and details of data-sources hidden from
+// a client.
+func restrictRecord(acc access.Access, mr *verif.MatchRecord) {
+	if mr == nil || !acc.IsRestricted() {
+		return
+	}
+	mr.MatchResults = acc.FilterResults(mr.MatchResults)
+	var details []vlib.DataSourceDetails
+	for _, v := range mr.DataSourcesDetails {
+		if acc.Allowed(v.DataSourceID) {
+			details = append(details, v)
+		}
+	}
+	mr.DataSourcesDetails = details
+	mr.DataSourcesNum = len(getDataSourcesIDs(mr.MatchResults))
+}
@@ -0,0 +1,57 @@
+package gnames
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gnames/gnames/pkg/ent/admin"
+)
+
+// ErrRemoteMatcher means that an operation requires the embedded matcher,
+// but a remote matcher service is used.
+var ErrRemoteMatcher = errors.New("matcher is remote")
+
+// AdminStatus collects information about caches of the embedded matcher,
+// the database connections pool, data-sources and cached results.
+func (g gnames) AdminStatus() (admin.Status, error) {
+	res := admin.Status{
+		MatcherURL:       g.cfg.MatcherURL,
+		DBPool:           g.vf.PoolStats(),
+		DataSourcesNum:   len(g.vf.DataSources()),
+		CachedResultsNum: g.vf.CachedResultsNum(),
+	}
+	cm, ok := g.matcher.(admin.CacheManager)
+	if !ok {
+		return res, nil
+	}
+	var err error
+	res.MatcherCaches, err = cm.CacheInfo()
+	if err != nil {
+		return res, fmt.Errorf("gnames.AdminStatus: %w", err)
+	}
+	return res, nil
+}
+
+// RebuildMatcherCache recreates caches of the embedded matcher from the
+// database.
+func (g gnames) RebuildMatcherCache() error {
+	cm, ok := g.matcher.(admin.CacheManager)
+	if !ok {
+		return fmt.Errorf("gnames.RebuildMatcherCache: %w", ErrRemoteMatcher)
+	}
+	slog.Info("Rebuilding matcher caches")
+	if err := cm.RebuildCache(); err != nil {
+		return fmt.Errorf("gnames.RebuildMatcherCache: %w", err)
+	}
+	slog.Info("Matcher caches are rebuilt")
+	return nil
+}
+
+// FlushDataSourceStats removes cached statistics of data-sources and
+// returns their number.
+func (g gnames) FlushDataSourceStats() int {
+	res := g.vf.FlushDataSourceStats()
+	slog.Info("Cached data-sources statistics removed", slog.Int("num", res))
+	return res
+}
@@ -0,0 +1,107 @@
+package gnames
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/lexgroup"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnuuid"
+	"github.com/google/uuid"
+)
+
+// Canonical lists name-strings that share a canonical form. Records are
+// returned by pages, name-strings of a page are grouped into lexical
+// groups.
+func (g gnames) Canonical(
+	ctx context.Context,
+	inp canonical.Input,
+) (canonical.Output, error) {
+	res := canonical.Output{
+		Meta: canonical.Meta{
+			Input:       inp.ID,
+			ID:          g.canonicalID(inp.ID, inp.Kind),
+			Kind:        inp.Kind,
+			DataSources: inp.DataSources,
+			Limit:       inp.PageLimit(),
+			Offset:      max(inp.Offset, 0),
+		},
+	}
+
+	acc := access.FromContext(ctx)
+	dss, ok := acc.Restrict(inp.DataSources)
+	if !ok {
+		return res, fmt.Errorf("gnames.Canonical: %w", dsrc.ErrNotFound)
+	}
+
+	// one more record shows if there is the next page.
+	can, rds, err := g.vf.CanonicalRecords(ctx, res.ID, inp.Kind, dss,
+		res.Limit+1, res.Offset)
+	if err != nil {
+		return res, fmt.Errorf("gnames.Canonical: %w", err)
+	}
+	if can == "" {
+		return res, fmt.Errorf("gnames.Canonical %q: %w", inp.ID,
+			canonical.ErrNotFound)
+	}
+	res.Canonical = can
+	if len(rds) > res.Limit {
+		rds = rds[:res.Limit]
+		res.NextOffset = res.Offset + res.Limit
+	}
+	rds = acc.FilterResults(rds)
+	if len(rds) == 0 {
+		return res, nil
+	}
+
+	names := make(map[string]struct{})
+	for _, rd := range rds {
+		names[rd.MatchedNameID] = struct{}{}
+	}
+	res.NameStringsNum = len(names)
+	res.RecordsNum = len(rds)
+
+	matchType := vlib.Exact
+	if rds[0].MatchType == vlib.Virus {
+		matchType = vlib.Virus
+	}
+	lgs := lexgroup.NameToLexicalGroups(
+		vlib.Name{MatchType: matchType, Results: rds},
+	)
+	res.Groups = make([]canonical.Group, len(lgs))
+	for i := range lgs {
+		res.Groups[i] = canonical.NewGroup(lgs[i])
+	}
+	res.GroupsNum = len(res.Groups)
+	return res, nil
+}
+
+// canonicalID returns UUID of a canonical form. If the input is not
+// a UUID, it is parsed, and its canonical form of the given kind is used.
+// Input that cannot be parsed is used as is.
+func (g gnames) canonicalID(idOrName string, kind canonical.Kind) string {
+	idOrName = strings.TrimSpace(idOrName)
+	if _, err := uuid.Parse(idOrName); err == nil {
+		return idOrName
+	}
+
+	can := idOrName
+	p := <-g.gnpPool
+	prsd := p.ParseName(idOrName)
+	g.gnpPool <- p
+	if prsd.Parsed && prsd.Canonical != nil {
+		switch kind {
+		case canonical.Full:
+			can = prsd.Canonical.Full
+		case canonical.Stem:
+			can = prsd.Canonical.Stemmed
+		default:
+			can = prsd.Canonical.Simple
+		}
+	}
+	return gnuuid.New(can).String()
+}
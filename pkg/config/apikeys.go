@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// APIKey describes a client of the service that is identified by a
+// secret key.
+type APIKey struct {
+	// Key is a secret sent by the client in X-API-Key header.
+	Key string `yaml:"Key"`
+
+	// Name of the client.
+	Name string `yaml:"Name"`
+
+	// DataSources are IDs of data-sources visible to the client. If it is
+	// empty, all data-sources are visible.
+	DataSources []int `yaml:"DataSources"`
+
+	// RateLimitVerify overrides Config.RateLimitVerify for the client.
+	RateLimitVerify int `yaml:"RateLimitVerify"`
+
+	// RateLimitSearch overrides Config.RateLimitSearch for the client.
+	RateLimitSearch int `yaml:"RateLimitSearch"`
+
+	// RateLimitReconcile overrides Config.RateLimitReconcile for the
+	// client.
+	RateLimitReconcile int `yaml:"RateLimitReconcile"`
+}
+
+// LoadAPIKeys reads API keys from a YAML file. The file contains a list of
+// keys with the same fields as APIKeys in the config file.
+func LoadAPIKeys(path string) ([]APIKey, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config.LoadAPIKeys: %w", err)
+	}
+	var res []APIKey
+	if err = yaml.Unmarshal(bs, &res); err != nil {
+		return nil, fmt.Errorf("config.LoadAPIKeys: %w", err)
+	}
+	for i, v := range res {
+		if v.Key == "" {
+			return nil, fmt.Errorf("config.LoadAPIKeys: empty key #%d", i+1)
+		}
+	}
+	return res, nil
+}
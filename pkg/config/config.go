@@ -51,6 +51,78 @@ type Config struct {
 
 	// PgUser is the PostgreSQL user with access to GNames database.
 	PgUser string
+
+	// DataSourcesReloadMin is the interval in minutes between reloads of
+	// data-sources metadata from the database. If it is 0, data-sources are
+	// reloaded only on demand.
+	DataSourcesReloadMin int
+
+	// ShutdownTimeoutSec is the time in seconds given to in-flight requests
+	// to finish when the service stops. Requests that are still running
+	// after the timeout are cancelled.
+	ShutdownTimeoutSec int
+
+	// MaxNamesPerRequest limits the number of name-strings in one
+	// verification or reconciliation request. Zero means no limit.
+	MaxNamesPerRequest int
+
+	// MaxConcurrentVerifications limits the number of verification requests
+	// processed at the same time. Zero means no limit.
+	MaxConcurrentVerifications int
+
+	// RateLimitVerify is the number of verification requests per minute
+	// allowed for one client. Clients are identified by an API key or by
+	// an IP address. Zero means no limit.
+	RateLimitVerify int
+
+	// RateLimitSearch is the number of search requests per minute allowed
+	// for one client. Zero means no limit.
+	RateLimitSearch int
+
+	// RateLimitReconcile is the number of reconciliation requests per minute
+	// allowed for one client. Zero means no limit.
+	RateLimitReconcile int
+
+	// APIKeys are keys of clients that can see data-sources hidden from
+	// anonymous users, and can have their own rate limits.
+	APIKeys []APIKey
+
+	// APIKeysFile is a path to a YAML file with additional API keys.
+	APIKeysFile string
+
+	// PublicDataSources are IDs of data-sources visible to clients without
+	// API keys. If it is empty, all data-sources are public.
+	PublicDataSources []int
+
+	// AdminToken is a secret token that gives access to the admin API.
+	// If it is empty, the admin API is disabled.
+	AdminToken string
+
+	// TrustedProxies are IP addresses or CIDR ranges of reverse proxies.
+	// Client IP addresses are taken from X-Forwarded-For header only for
+	// requests that come from these proxies. If it is empty, the address
+	// of the connection is used.
+	TrustedProxies []string
+}
+
+// Redacted returns a copy of the configuration without passwords and
+// tokens, so it can be shown or logged.
+func (cnf Config) Redacted() Config {
+	res := cnf
+	if res.PgPass != "" {
+		res.PgPass = redacted
+	}
+	if res.AdminToken != "" {
+		res.AdminToken = redacted
+	}
+	if len(res.APIKeys) > 0 {
+		res.APIKeys = make([]APIKey, len(cnf.APIKeys))
+		for i, v := range cnf.APIKeys {
+			v.Key = redacted
+			res.APIKeys[i] = v
+		}
+	}
+	return res
 }
 
 // TrieDir returns path where to dump/restore
@@ -71,6 +143,9 @@ func (cnf Config) StemsDir() string {
 	return filepath.Join(cnf.CacheDir, "stems-kv")
 }
 
+// redacted replaces secrets in a redacted configuration.
+const redacted = "*****"
+
 // Option is a type of all options for Config.
 type Option func(cnf *Config)
 
@@ -167,6 +242,97 @@ func OptGnamesHostURL(s string) Option {
 	}
 }
 
+// OptDataSourcesReloadMin sets the interval in minutes between reloads of
+// data-sources metadata. Zero or negative value disables periodic reloads.
+func OptDataSourcesReloadMin(i int) Option {
+	return func(cnf *Config) {
+		cnf.DataSourcesReloadMin = max(i, 0)
+	}
+}
+
+// OptShutdownTimeoutSec sets the time in seconds for draining in-flight
+// requests during shutdown.
+func OptShutdownTimeoutSec(i int) Option {
+	return func(cnf *Config) {
+		cnf.ShutdownTimeoutSec = max(i, 0)
+	}
+}
+
+// OptMaxNamesPerRequest limits the number of name-strings in one request.
+func OptMaxNamesPerRequest(i int) Option {
+	return func(cnf *Config) {
+		cnf.MaxNamesPerRequest = max(i, 0)
+	}
+}
+
+// OptMaxConcurrentVerifications limits the number of verifications that
+// run at the same time.
+func OptMaxConcurrentVerifications(i int) Option {
+	return func(cnf *Config) {
+		cnf.MaxConcurrentVerifications = max(i, 0)
+	}
+}
+
+// OptRateLimitVerify sets the number of verification requests per minute
+// for one client.
+func OptRateLimitVerify(i int) Option {
+	return func(cnf *Config) {
+		cnf.RateLimitVerify = max(i, 0)
+	}
+}
+
+// OptRateLimitSearch sets the number of search requests per minute for
+// one client.
+func OptRateLimitSearch(i int) Option {
+	return func(cnf *Config) {
+		cnf.RateLimitSearch = max(i, 0)
+	}
+}
+
+// OptRateLimitReconcile sets the number of reconciliation requests per
+// minute for one client.
+func OptRateLimitReconcile(i int) Option {
+	return func(cnf *Config) {
+		cnf.RateLimitReconcile = max(i, 0)
+	}
+}
+
+// OptAPIKeys sets API keys of clients.
+func OptAPIKeys(keys []APIKey) Option {
+	return func(cnf *Config) {
+		cnf.APIKeys = keys
+	}
+}
+
+// OptAPIKeysFile sets the path to a file with API keys.
+func OptAPIKeysFile(s string) Option {
+	return func(cnf *Config) {
+		cnf.APIKeysFile, _ = gnsys.ConvertTilda(s)
+	}
+}
+
+// OptPublicDataSources sets IDs of data-sources visible to anonymous
+// clients.
+func OptPublicDataSources(ids []int) Option {
+	return func(cnf *Config) {
+		cnf.PublicDataSources = ids
+	}
+}
+
+// OptAdminToken sets the token for the admin API.
+func OptAdminToken(s string) Option {
+	return func(cnf *Config) {
+		cnf.AdminToken = s
+	}
+}
+
+// OptTrustedProxies sets IP addresses or CIDR ranges of reverse proxies.
+func OptTrustedProxies(ss []string) Option {
+	return func(cnf *Config) {
+		cnf.TrustedProxies = ss
+	}
+}
+
 // New is a Config constructor that takes options to
 // update default values.
 func New(opts ...Option) Config {
@@ -184,6 +350,8 @@ func New(opts ...Option) Config {
 		PgPort:        5432,
 		PgUser:        "postgres",
 		Port:          8888,
+
+		ShutdownTimeoutSec: 30,
 	}
 
 	for _, opt := range opts {
@@ -26,6 +26,8 @@ func TestNew(t *testing.T) {
 		MatcherURL:    "",
 		WebPageURL:    "https://verifier.globalnames.org",
 		GnamesHostURL: "https://verifier.globalnames.org",
+
+		ShutdownTimeoutSec: 30,
 	}
 	assert.Equal(t, deflt, cnf)
 }
@@ -47,10 +49,67 @@ func TestNewOpts(t *testing.T) {
 		MatcherURL:    "",
 		WebPageURL:    "https://example.org",
 		GnamesHostURL: "https://example.com",
+
+		DataSourcesReloadMin: 30,
+		ShutdownTimeoutSec:   10,
+		AdminToken:           "token",
+
+		APIKeys: []config.APIKey{
+			{Key: "key1", Name: "partner", DataSources: []int{1, 3}},
+		},
+		PublicDataSources: []int{1},
+		TrustedProxies:    []string{"10.0.0.1"},
+
+		MaxNamesPerRequest:         1000,
+		MaxConcurrentVerifications: 4,
+		RateLimitVerify:            60,
+		RateLimitSearch:            120,
+		RateLimitReconcile:         30,
 	}
 	assert.Equal(t, updt, cnf)
 }
 
+func TestRedacted(t *testing.T) {
+	cnf := config.New(opts()...)
+	red := cnf.Redacted()
+	assert.NotEqual(t, "secret", red.PgPass)
+	assert.NotEqual(t, "token", red.AdminToken)
+	assert.Equal(t, "secret", cnf.PgPass)
+	assert.Equal(t, cnf.PgHost, red.PgHost)
+	assert.NotEqual(t, "key1", red.APIKeys[0].Key)
+	assert.Equal(t, "key1", cnf.APIKeys[0].Key)
+	assert.Equal(t, "partner", red.APIKeys[0].Name)
+
+	cnf = config.New(config.OptPgPass(""))
+	assert.Empty(t, cnf.Redacted().PgPass)
+	assert.Empty(t, cnf.Redacted().AdminToken)
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	err := os.WriteFile(path, []byte(`
+- Key: key1
+  Name: partner
+  DataSources: [1, 3]
+  RateLimitVerify: 100
+- Key: key2
+`), 0644)
+	assert.Nil(t, err)
+
+	keys, err := config.LoadAPIKeys(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []config.APIKey{
+		{Key: "key1", Name: "partner", DataSources: []int{1, 3},
+			RateLimitVerify: 100},
+		{Key: "key2"},
+	}, keys)
+
+	err = os.WriteFile(path, []byte("- Name: no key\n"), 0644)
+	assert.Nil(t, err)
+	_, err = config.LoadAPIKeys(path)
+	assert.NotNil(t, err)
+}
+
 func TestMaxED(t *testing.T) {
 	cnf := config.New(config.OptMaxEditDist(5))
 	assert.Equal(t, 1, cnf.MaxEditDist)
@@ -70,5 +129,18 @@ func opts() []config.Option {
 		config.OptPgDB("gnm"),
 		config.OptWebPageURL("https://example.org"),
 		config.OptGnamesHostURL("https://example.com"),
+		config.OptDataSourcesReloadMin(30),
+		config.OptShutdownTimeoutSec(10),
+		config.OptAdminToken("token"),
+		config.OptAPIKeys([]config.APIKey{
+			{Key: "key1", Name: "partner", DataSources: []int{1, 3}},
+		}),
+		config.OptPublicDataSources([]int{1}),
+		config.OptTrustedProxies([]string{"10.0.0.1"}),
+		config.OptMaxNamesPerRequest(1000),
+		config.OptMaxConcurrentVerifications(4),
+		config.OptRateLimitVerify(60),
+		config.OptRateLimitSearch(120),
+		config.OptRateLimitReconcile(30),
 	}
 }
@@ -13,6 +13,8 @@ func LoadEnv(c *Config) {
 	slog.Info("Updating config using environment variables")
 	opts := strOpts()
 	opts = append(opts, intOpts()...)
+	opts = append(opts, intsOpts()...)
+	opts = append(opts, strsOpts()...)
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -29,6 +31,8 @@ func strOpts() []Option {
 		"GN_PG_USER":         OptPgUser,
 		"GN_PG_PASS":         OptPgPass,
 		"GN_PG_DB":           OptPgDB,
+		"GN_ADMIN_TOKEN":     OptAdminToken,
+		"GN_API_KEYS_FILE":   OptAPIKeysFile,
 	}
 
 	for envVar, optFunc := range envToOpt {
@@ -48,6 +52,15 @@ func intOpts() []Option {
 		"GN_PG_PORT":       OptPgPort,
 		"GN_JOBS_NUM":      OptJobsNum,
 		"GN_MAX_EDIT_DIST": OptMaxEditDist,
+
+		"GN_DATA_SOURCES_RELOAD_MIN": OptDataSourcesReloadMin,
+		"GN_SHUTDOWN_TIMEOUT_SEC":    OptShutdownTimeoutSec,
+
+		"GN_MAX_NAMES_PER_REQUEST":        OptMaxNamesPerRequest,
+		"GN_MAX_CONCURRENT_VERIFICATIONS": OptMaxConcurrentVerifications,
+		"GN_RATE_LIMIT_VERIFY":            OptRateLimitVerify,
+		"GN_RATE_LIMIT_SEARCH":            OptRateLimitSearch,
+		"GN_RATE_LIMIT_RECONCILE":         OptRateLimitReconcile,
 	}
 	for envVar, optFunc := range envToOpt {
 		val := strings.TrimSpace(os.Getenv(envVar))
@@ -63,3 +76,62 @@ func intOpts() []Option {
 	}
 	return res
 }
+
+func intsOpts() []Option {
+	var res []Option
+	envToOpt := map[string]func([]int) Option{
+		"GN_PUBLIC_DATA_SOURCES": OptPublicDataSources,
+	}
+	for envVar, optFunc := range envToOpt {
+		val := strings.TrimSpace(os.Getenv(envVar))
+		if val == "" {
+			continue
+		}
+		ints, err := parseInts(val)
+		if err != nil {
+			slog.Warn("Cannot convert to ints", "env", envVar, "value", val)
+			continue
+		}
+		res = append(res, optFunc(ints))
+	}
+	return res
+}
+
+func strsOpts() []Option {
+	var res []Option
+	envToOpt := map[string]func([]string) Option{
+		"GN_TRUSTED_PROXIES": OptTrustedProxies,
+	}
+	for envVar, optFunc := range envToOpt {
+		val := strings.TrimSpace(os.Getenv(envVar))
+		if val == "" {
+			continue
+		}
+		res = append(res, optFunc(parseStrs(val)))
+	}
+	return res
+}
+
+// parseStrs converts a comma-separated list of strings.
+func parseStrs(s string) []string {
+	var res []string
+	for v := range strings.SplitSeq(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// parseInts converts a comma-separated list of integers.
+func parseInts(s string) ([]int, error) {
+	var res []int
+	for v := range strings.SplitSeq(s, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, i)
+	}
+	return res, nil
+}
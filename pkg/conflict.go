@@ -0,0 +1,44 @@
+package gnames
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+func (g gnames) Conflicts(
+	ctx context.Context,
+	inp conflict.Input,
+) (conflict.Output, error) {
+	res := conflict.Output{Input: inp.Name}
+	input := verif.Input{
+		Input:         vlib.Input{NameStrings: []string{inp.Name}},
+		WithConflicts: true,
+		WithConsensus: inp.WithConsensus,
+	}
+	for _, v := range inp.DataSources {
+		input.DataSources = append(input.DataSources, strconv.Itoa(v))
+	}
+
+	out, err := g.VerifyWithOptions(ctx, input)
+	if err != nil {
+		return res, fmt.Errorf("gnames.Conflicts: %w", err)
+	}
+	if len(out.Names) == 0 {
+		return res, nil
+	}
+
+	name := out.Names[0]
+	if name.BestResult != nil {
+		res.MatchedName = name.BestResult.MatchedName
+	}
+	if name.Conflicts != nil {
+		res.Report = *name.Conflicts
+	}
+	res.Consensus = name.Consensus
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package gnames
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/gnames/gnames/pkg/ent/score"
+	"github.com/gnames/gnames/pkg/ent/taxstats"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnstats/ent/stats"
+)
+
+// rankByContext finds the main taxon of all names and moves results from
+// the main taxon ahead of their homonyms outside of it. The main taxon is
+// found from classifications of statsMrs. It returns the main taxon and
+// flags of names which best result changed because of the context.
+func rankByContext(
+	input verif.Input,
+	mrs, statsMrs []*verif.MatchRecord,
+) (string, []bool) {
+	changed := make([]bool, len(mrs))
+	s := score.New()
+	for _, mr := range slices.Concat(mrs, statsMrs) {
+		if mr != nil && !mr.Sorted {
+			s.SortResults(mr)
+		}
+	}
+
+	items := statsItems(statsMrs, input.StatsDataSource)
+	st := stats.New(taxstats.Hierarchies(items), input.MainTaxonThreshold)
+	taxon := st.MainTaxon.Name
+	if taxon == "" {
+		return taxon, changed
+	}
+
+	// the same name can be given several times
+	done := make(map[*verif.MatchRecord]bool)
+	for i, mr := range mrs {
+		if mr == nil {
+			continue
+		}
+		if _, ok := done[mr]; !ok {
+			done[mr] = contextSort(mr.MatchResults, taxon)
+		}
+		changed[i] = done[mr]
+	}
+	return taxon, changed
+}
+
+// contextSort moves results from the taxon ahead of the best result, if
+// the best result is outside of the taxon. Only results with the same
+// canonical form as the best result are moved, so the context does not
+// override the quality of matching. It returns true if the best result
+// changed.
+func contextSort(rds []*vlib.ResultData, taxon string) bool {
+	if len(rds) < 2 || inTaxon(rds[0], taxon) {
+		return false
+	}
+	can := rds[0].MatchedCanonicalSimple
+	idx := slices.IndexFunc(rds, func(rd *vlib.ResultData) bool {
+		return rd.MatchedCanonicalSimple == can && inTaxon(rd, taxon)
+	})
+	if idx == -1 {
+		return false
+	}
+
+	rank := func(rd *vlib.ResultData) int {
+		switch {
+		case rd.MatchedCanonicalSimple != can:
+			return 2
+		case inTaxon(rd, taxon):
+			return 0
+		default:
+			return 1
+		}
+	}
+	slices.SortStableFunc(rds, func(a, b *vlib.ResultData) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+	return true
+}
+
+// inTaxon checks if the classification of a result contains the taxon.
+func inTaxon(rd *vlib.ResultData, taxon string) bool {
+	for v := range strings.SplitSeq(rd.ClassificationPath, "|") {
+		if v == taxon {
+			return true
+		}
+	}
+	return false
+}
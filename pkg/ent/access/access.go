@@ -0,0 +1,83 @@
+// Package access contains entities that limit data-sources visible to
+// clients of the service.
+package access
+
+import (
+	"context"
+	"slices"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Access describes data-sources visible to a client.
+type Access struct {
+	// Client is a name of the client, for example an owner of an API key.
+	// It is empty for anonymous clients.
+	Client string
+
+	// DataSources are IDs of visible data-sources. If it is empty, all
+	// data-sources are visible.
+	DataSources []int
+}
+
+// IsRestricted is true if some data-sources are hidden from the client.
+func (a Access) IsRestricted() bool {
+	return len(a.DataSources) > 0
+}
+
+// Allowed is true if a data-source is visible to the client.
+func (a Access) Allowed(id int) bool {
+	return !a.IsRestricted() || slices.Contains(a.DataSources, id)
+}
+
+// Restrict limits requested IDs of data-sources to the visible ones. If no
+// IDs are requested, all visible IDs are returned. The boolean is false
+// if some of the requested data-sources are hidden.
+func (a Access) Restrict(ids []int) ([]int, bool) {
+	if !a.IsRestricted() {
+		return ids, true
+	}
+	if len(ids) == 0 {
+		return slices.Clone(a.DataSources), true
+	}
+	for _, id := range ids {
+		if !a.Allowed(id) {
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+// FilterResults removes results from hidden data-sources.
+func (a Access) FilterResults(rds []*vlib.ResultData) []*vlib.ResultData {
+	if !a.IsRestricted() {
+		return rds
+	}
+	return slices.DeleteFunc(slices.Clone(rds), func(rd *vlib.ResultData) bool {
+		return !a.Allowed(rd.DataSourceID)
+	})
+}
+
+// FilterDataSources removes hidden data-sources.
+func (a Access) FilterDataSources(dss []*vlib.DataSource) []*vlib.DataSource {
+	if !a.IsRestricted() {
+		return dss
+	}
+	return slices.DeleteFunc(slices.Clone(dss), func(ds *vlib.DataSource) bool {
+		return !a.Allowed(ds.ID)
+	})
+}
+
+type ctxKey struct{}
+
+// NewContext returns a context that carries the access of a client.
+func NewContext(ctx context.Context, a Access) context.Context {
+	return context.WithValue(ctx, ctxKey{}, a)
+}
+
+// FromContext returns the access of a client. Without access information
+// in the context all data-sources are visible.
+func FromContext(ctx context.Context) Access {
+	a, _ := ctx.Value(ctxKey{}).(Access)
+	return a
+}
@@ -0,0 +1,58 @@
+package access_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/access"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestrict(t *testing.T) {
+	tests := []struct {
+		msg  string
+		dss  []int
+		ids  []int
+		res  []int
+		isOK bool
+	}{
+		{"no restriction", nil, []int{1, 3}, []int{1, 3}, true},
+		{"no restriction, no ids", nil, nil, nil, true},
+		{"no ids", []int{1, 2}, nil, []int{1, 2}, true},
+		{"visible ids", []int{1, 2, 3}, []int{3, 1}, []int{3, 1}, true},
+		{"hidden id", []int{1, 2}, []int{1, 5}, nil, false},
+	}
+	for _, v := range tests {
+		a := access.Access{DataSources: v.dss}
+		res, ok := a.Restrict(v.ids)
+		assert.Equal(t, v.isOK, ok, v.msg)
+		assert.Equal(t, v.res, res, v.msg)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	rds := []*vlib.ResultData{
+		{DataSourceID: 1}, {DataSourceID: 2}, {DataSourceID: 3},
+	}
+	dss := []*vlib.DataSource{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	a := access.Access{}
+	assert.Len(t, a.FilterResults(rds), 3)
+	assert.Len(t, a.FilterDataSources(dss), 3)
+
+	a = access.Access{DataSources: []int{1, 3}}
+	res := a.FilterResults(rds)
+	assert.Equal(t, []*vlib.ResultData{rds[0], rds[2]}, res)
+	assert.Len(t, rds, 3)
+	assert.Equal(t, []*vlib.DataSource{dss[0], dss[2]}, a.FilterDataSources(dss))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, access.FromContext(ctx).IsRestricted())
+
+	a := access.Access{Client: "partner", DataSources: []int{1}}
+	ctx = access.NewContext(ctx, a)
+	assert.Equal(t, a, access.FromContext(ctx))
+}
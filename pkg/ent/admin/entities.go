@@ -0,0 +1,92 @@
+// Package admin contains entities for runtime diagnostics and maintenance
+// of the service.
+package admin
+
+// Status describes the runtime state of the service.
+type Status struct {
+	// MatcherURL is the URL of a remote matcher. It is empty if the
+	// matcher is embedded.
+	MatcherURL string `json:"matcherUrl,omitempty"`
+
+	// MatcherCaches describe caches of the embedded matcher.
+	MatcherCaches []CacheInfo `json:"matcherCaches,omitempty"`
+
+	// DBPool describes the state of the database connections pool.
+	DBPool PoolStats `json:"dbPool"`
+
+	// DataSourcesNum is the number of known data-sources.
+	DataSourcesNum int `json:"dataSourcesNum"`
+
+	// CachedResultsNum is the number of cached results, for example
+	// data-sources statistics.
+	CachedResultsNum int `json:"cachedResultsNum"`
+}
+
+// CacheInfo describes a cache stored on disk.
+type CacheInfo struct {
+	// Name of the cache, for example `bloom` or `trie`.
+	Name string `json:"name"`
+
+	// Path to the cache directory.
+	Path string `json:"path"`
+
+	// SizeBytes is the size of all cache files.
+	SizeBytes int64 `json:"sizeBytes"`
+
+	// FilesNum is the number of cache files.
+	FilesNum int `json:"filesNum"`
+
+	// BuiltAt is the modification time of the newest cache file.
+	BuiltAt string `json:"builtAt,omitempty"`
+}
+
+// PoolStats describe connections to the database.
+type PoolStats struct {
+	// MaxConns is the maximal size of the pool.
+	MaxConns int `json:"maxConns"`
+
+	// TotalConns is the number of open connections.
+	TotalConns int `json:"totalConns"`
+
+	// AcquiredConns is the number of connections in use.
+	AcquiredConns int `json:"acquiredConns"`
+
+	// IdleConns is the number of unused open connections.
+	IdleConns int `json:"idleConns"`
+
+	// AcquireCount is the number of times a connection was acquired.
+	AcquireCount int64 `json:"acquireCount"`
+
+	// EmptyAcquireCount is the number of times a request had to wait for a
+	// connection because the pool had no idle connections.
+	EmptyAcquireCount int64 `json:"emptyAcquireCount"`
+
+	// AcquireDuration is the total time spent on acquiring connections.
+	AcquireDuration string `json:"acquireDuration"`
+}
+
+// Readiness reports if the service and its dependencies are able to
+// serve requests.
+type Readiness struct {
+	// Ready is true if all components are ready.
+	Ready bool `json:"ready"`
+
+	// Components describe the state of every dependency.
+	Components []Component `json:"components"`
+}
+
+// Component describes the result of a readiness check of one dependency
+// of the service.
+type Component struct {
+	// Name of the component, for example `database` or `matcher`.
+	Name string `json:"name"`
+
+	// Ready is true if the check succeeded.
+	Ready bool `json:"ready"`
+
+	// LatencyMs is the duration of the check in milliseconds.
+	LatencyMs float64 `json:"latencyMs"`
+
+	// Error explains why the component is not ready.
+	Error string `json:"error,omitempty"`
+}
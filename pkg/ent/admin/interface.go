@@ -0,0 +1,20 @@
+package admin
+
+// CacheManager is implemented by components that keep caches on disk, for
+// example by the embedded matcher.
+type CacheManager interface {
+	// CacheInfo returns sizes and build times of the caches.
+	CacheInfo() ([]CacheInfo, error)
+
+	// RebuildCache creates caches again from the database and replaces
+	// the old ones with them.
+	RebuildCache() error
+}
+
+// Initializer is implemented by components that are initialized in the
+// background, for example by the embedded matcher that builds its caches.
+type Initializer interface {
+	// InitErr returns nil if the component is initialized. Otherwise it
+	// returns the reason why the component cannot be used yet.
+	InitErr() error
+}
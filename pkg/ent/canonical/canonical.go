@@ -0,0 +1,240 @@
+// Package canonical provides entities for listing name-strings that share
+// the same canonical form.
+package canonical
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gnames/gnames/pkg/ent/lexgroup"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// ErrKind is returned for unknown kinds of canonical forms.
+var ErrKind = errors.New("unknown kind of canonical form")
+
+// ErrNotFound is returned if the canonical form is not known.
+var ErrNotFound = errors.New("canonical form not found")
+
+// Limits of the number of records in one response.
+const (
+	// DefaultLimit is used if the limit is not set.
+	DefaultLimit = 1000
+
+	// MaxLimit is the largest allowed limit.
+	MaxLimit = 5000
+)
+
+// Kind is the kind of canonical forms.
+type Kind int
+
+// Kinds of canonical forms.
+const (
+	// Simple canonical form has no ranks and hybrid signs, for example
+	// `Carex scirpoidea convoluta`.
+	Simple Kind = iota
+
+	// Full canonical form keeps ranks of infraspecies and hybrid signs,
+	// for example `Carex scirpoidea var. convoluta`.
+	Full
+
+	// Stem canonical form has stemmed specific epithets, for example
+	// `Carex scirpoide conuolut`.
+	Stem
+)
+
+var kinds = []string{"simple", "full", "stem"}
+
+// NewKind converts a string to Kind. Empty string means Simple.
+func NewKind(s string) (Kind, error) {
+	if s == "" {
+		return Simple, nil
+	}
+	for i, v := range kinds {
+		if v == s {
+			return Kind(i), nil
+		}
+	}
+	return Simple, fmt.Errorf("canonical.NewKind %q: %w", s, ErrKind)
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kinds) {
+		return "unknown"
+	}
+	return kinds[k]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Input contains parameters of a canonical form lookup.
+type Input struct {
+	// ID is a UUID of a canonical form, or a name. Canonical form of the
+	// name is used for the lookup.
+	ID string
+
+	// Kind of the canonical form.
+	Kind Kind
+
+	// DataSources limit records to these data-sources. If empty, all
+	// data-sources are used.
+	DataSources []int
+
+	// Limit is the largest number of records in the output. It is set to
+	// DefaultLimit if it is not positive, and to MaxLimit if it is larger
+	// than MaxLimit.
+	Limit int
+
+	// Offset is the number of records to skip.
+	Offset int
+}
+
+// PageLimit returns the limit of records within allowed bounds.
+func (inp Input) PageLimit() int {
+	switch {
+	case inp.Limit <= 0:
+		return DefaultLimit
+	case inp.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return inp.Limit
+	}
+}
+
+// Output contains name-strings of a canonical form grouped into lexical
+// groups.
+type Output struct {
+	Meta `json:"meta"`
+
+	// Groups are lexical groups of name-strings. Name-strings of a group
+	// seem to belong to the same scientific name, groups differ by
+	// authorship or ranks.
+	Groups []Group `json:"groups"`
+}
+
+// Meta contains the canonical form and the summary of the lookup.
+type Meta struct {
+	// Input is the ID or the name as given.
+	Input string `json:"input"`
+
+	// ID is the UUID of the canonical form.
+	ID string `json:"id"`
+
+	// Kind of the canonical form.
+	Kind Kind `json:"kind"`
+
+	// Canonical is the canonical form, it is empty if the canonical form
+	// is unknown.
+	Canonical string `json:"canonical,omitempty"`
+
+	// DataSources limit records.
+	DataSources []int `json:"dataSources,omitempty"`
+
+	// NameStringsNum is the number of name-strings.
+	NameStringsNum int `json:"nameStringsNum"`
+
+	// RecordsNum is the number of data-source records.
+	RecordsNum int `json:"recordsNum"`
+
+	// GroupsNum is the number of lexical groups.
+	GroupsNum int `json:"groupsNum"`
+
+	// Limit is the largest number of records in the output. Counts of
+	// name-strings, records and groups are for this page of records.
+	Limit int `json:"limit"`
+
+	// Offset is the number of skipped records.
+	Offset int `json:"offset,omitempty"`
+
+	// NextOffset is the offset of the next page of records. It is zero if
+	// there are no more records.
+	NextOffset int `json:"nextOffset,omitempty"`
+}
+
+// Group is a lexical group of name-strings.
+type Group struct {
+	// ID is the UUID of the name-string that represents the group.
+	ID string `json:"id"`
+
+	// Name is the name-string that represents the group.
+	Name string `json:"name"`
+
+	// NomCodes are nomenclatural codes detected for the group.
+	NomCodes []string `json:"nomCodes,omitempty"`
+
+	// NameStrings are lexical variants of the group.
+	NameStrings []NameString `json:"nameStrings"`
+}
+
+// NameString is a spelling of a name with data-sources that use it.
+type NameString struct {
+	// ID is the UUID of the name-string.
+	ID string `json:"id"`
+
+	// Name is the name-string.
+	Name string `json:"name"`
+
+	// Authors of the name-string.
+	Authors []string `json:"authors,omitempty"`
+
+	// Year of the name-string.
+	Year int `json:"year,omitempty"`
+
+	// DataSources are IDs of data-sources that use the name-string.
+	DataSources []int `json:"dataSources"`
+
+	// RecordsNum is the number of records with the name-string.
+	RecordsNum int `json:"recordsNum"`
+}
+
+// NewGroup converts a lexical group to Group. Records of the lexical
+// group are collected into name-strings in the order of their first
+// appearance.
+func NewGroup(lg lexgroup.LexicalGroup) Group {
+	res := Group{ID: lg.ID, Name: lg.Name}
+	for k := range lg.NomCodes {
+		res.NomCodes = append(res.NomCodes, k)
+	}
+	slices.Sort(res.NomCodes)
+
+	idx := make(map[string]int)
+	seen := make(map[string]struct{})
+	for _, rd := range lg.Data {
+		key := fmt.Sprintf("%s|%d|%s",
+			rd.MatchedNameID, rd.DataSourceID, rd.RecordID)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		i, ok := idx[rd.MatchedNameID]
+		if !ok {
+			i = len(res.NameStrings)
+			idx[rd.MatchedNameID] = i
+			res.NameStrings = append(res.NameStrings, newNameString(rd))
+		}
+		ns := &res.NameStrings[i]
+		ns.RecordsNum++
+		if !slices.Contains(ns.DataSources, rd.DataSourceID) {
+			ns.DataSources = append(ns.DataSources, rd.DataSourceID)
+		}
+	}
+	for i := range res.NameStrings {
+		slices.Sort(res.NameStrings[i].DataSources)
+	}
+	return res
+}
+
+func newNameString(rd *vlib.ResultData) NameString {
+	return NameString{
+		ID:      rd.MatchedNameID,
+		Name:    rd.MatchedName,
+		Authors: rd.MatchedAuthors,
+		Year:    rd.MatchedYear,
+	}
+}
@@ -0,0 +1,70 @@
+package canonical_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/lexgroup"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind(t *testing.T) {
+	assert := assert.New(t)
+	for i, v := range []string{"simple", "full", "stem"} {
+		k, err := canonical.NewKind(v)
+		assert.Nil(err)
+		assert.Equal(canonical.Kind(i), k)
+		assert.Equal(v, k.String())
+	}
+	k, err := canonical.NewKind("")
+	assert.Nil(err)
+	assert.Equal(canonical.Simple, k)
+
+	_, err = canonical.NewKind("stemmed")
+	assert.ErrorIs(err, canonical.ErrKind)
+}
+
+func TestPageLimit(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		limit, res int
+	}{
+		{0, canonical.DefaultLimit},
+		{-1, canonical.DefaultLimit},
+		{10, 10},
+		{canonical.MaxLimit + 1, canonical.MaxLimit},
+	}
+	for _, v := range tests {
+		assert.Equal(v.res, canonical.Input{Limit: v.limit}.PageLimit())
+	}
+}
+
+func TestNewGroup(t *testing.T) {
+	assert := assert.New(t)
+	rd1 := &vlib.ResultData{
+		DataSourceID: 12, RecordID: "a", MatchedNameID: "id1",
+		MatchedName: "Bubo bubo L.", MatchedAuthors: []string{"L."},
+		ClassificationPath: "Animalia|Chordata",
+	}
+	rd2 := &vlib.ResultData{
+		DataSourceID: 1, RecordID: "b", MatchedNameID: "id1",
+		MatchedName: "Bubo bubo L.",
+	}
+	rd3 := &vlib.ResultData{
+		DataSourceID: 1, RecordID: "c", MatchedNameID: "id2",
+		MatchedName: "Bubo bubo",
+	}
+	lg := lexgroup.New(rd1)
+	lg.Data = append(lg.Data, rd1, rd2, rd3)
+
+	res := canonical.NewGroup(lg)
+	assert.Equal("id1", res.ID)
+	assert.Equal([]string{"ICZN"}, res.NomCodes)
+	assert.Len(res.NameStrings, 2)
+	assert.Equal([]int{1, 12}, res.NameStrings[0].DataSources)
+	assert.Equal(2, res.NameStrings[0].RecordsNum)
+	assert.Equal([]string{"L."}, res.NameStrings[0].Authors)
+	assert.Equal("id2", res.NameStrings[1].ID)
+	assert.Equal(1, res.NameStrings[1].RecordsNum)
+}
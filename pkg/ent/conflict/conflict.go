@@ -0,0 +1,224 @@
+// Package conflict compares opinions of data-sources about the same name.
+// It finds disagreements between data-sources and their consensus.
+// Data-sources can have different taxonomic status for a name, point to
+// different currently accepted names, or place the name into different
+// higher taxa.
+package conflict
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Ranks are ranks of classification that are compared between
+// data-sources.
+var Ranks = []string{"kingdom", "phylum", "class", "order", "family", "genus"}
+
+// Points of disagreement that are not ranks of classification.
+const (
+	// TaxonomicStatus is disagreement on the name being accepted or a
+	// synonym.
+	TaxonomicStatus = "taxonomicStatus"
+
+	// CurrentName is disagreement on the currently accepted name.
+	CurrentName = "currentName"
+)
+
+// Input contains parameters of a conflicts lookup.
+type Input struct {
+	// Name is a name-string to verify.
+	Name string `json:"name"`
+
+	// DataSources limit compared data-sources. If empty, all curated
+	// data-sources are compared.
+	DataSources []int `json:"dataSources,omitempty"`
+
+	// WithConsensus adds the consensus of data-sources to the output.
+	WithConsensus bool `json:"withConsensus,omitempty"`
+}
+
+// Output contains disagreements of data-sources about a name.
+type Output struct {
+	// Input is the name-string as given.
+	Input string `json:"input"`
+
+	// MatchedName is the best match of the name-string. It is empty if
+	// the name-string is not found.
+	MatchedName string `json:"matchedName,omitempty"`
+
+	// Report contains disagreements about the matched name.
+	Report `json:"report"`
+
+	// Consensus is the aggregated opinion of data-sources about the
+	// matched name. It is created only if WithConsensus option is given.
+	Consensus *Consensus `json:"consensus,omitempty"`
+}
+
+// Report contains disagreements between data-sources about a name.
+type Report struct {
+	// DataSourcesNum is the number of compared data-sources.
+	DataSourcesNum int `json:"dataSourcesNum"`
+
+	// DataSources are IDs of compared data-sources.
+	DataSources []int `json:"dataSources,omitempty"`
+
+	// Conflicts are points of disagreement, in the order of taxonomic
+	// status, current name and ranks from kingdom to genus.
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+}
+
+// Conflict is a point of disagreement between data-sources.
+type Conflict struct {
+	// Field is `taxonomicStatus`, `currentName` or a rank of
+	// classification.
+	Field string `json:"field"`
+
+	// Sides are the values of the field with data-sources that support
+	// them. Sides with more data-sources go first.
+	Sides []Side `json:"sides"`
+}
+
+// Side is a value of a field and data-sources that support it.
+type Side struct {
+	// Value of the field.
+	Value string `json:"value"`
+
+	// DataSources are IDs of data-sources that have the value.
+	DataSources []int `json:"dataSources"`
+
+	// DataSourceTitles are short titles of the data-sources.
+	DataSourceTitles []string `json:"dataSourceTitles"`
+}
+
+// IsEmpty returns true if data-sources do not disagree.
+func (r Report) IsEmpty() bool {
+	return len(r.Conflicts) == 0
+}
+
+// New compares matched results of a name. Only results that match the
+// same canonical form as the first result are compared, one result per
+// data-source. Results from data-sources that are not curated are ignored,
+// as well as the data that are missing in a data-source. Results are
+// expected to be sorted from the best to the worst.
+func New(rds []*vlib.ResultData) Report {
+	var res Report
+	rds = compared(rds)
+	if len(rds) == 0 {
+		return res
+	}
+	res.DataSourcesNum = len(rds)
+	for _, v := range rds {
+		res.DataSources = append(res.DataSources, v.DataSourceID)
+	}
+	slices.Sort(res.DataSources)
+	if len(rds) < 2 {
+		return res
+	}
+
+	status := func(i int) string {
+		if rds[i].TaxonomicStatus == vlib.UnknownTaxStatus {
+			return ""
+		}
+		return rds[i].TaxonomicStatus.String()
+	}
+	current := func(i int) string {
+		return rds[i].CurrentCanonicalSimple
+	}
+	if c, ok := compare(TaxonomicStatus, rds, status); ok {
+		res.Conflicts = append(res.Conflicts, c)
+	}
+	if c, ok := compare(CurrentName, rds, current); ok {
+		res.Conflicts = append(res.Conflicts, c)
+	}
+
+	ranks := make([]map[string]string, len(rds))
+	for i := range rds {
+		ranks[i] = rankNames(rds[i])
+	}
+	for _, rank := range Ranks {
+		val := func(i int) string { return ranks[i][rank] }
+		if c, ok := compare(rank, rds, val); ok {
+			res.Conflicts = append(res.Conflicts, c)
+		}
+	}
+	return res
+}
+
+// compared selects results for comparison.
+func compared(rds []*vlib.ResultData) []*vlib.ResultData {
+	var res []*vlib.ResultData
+	if len(rds) == 0 {
+		return res
+	}
+	can := rds[0].MatchedCanonicalSimple
+	seen := make(map[int]struct{})
+	for _, v := range rds {
+		if v.Curation == vlib.NotCurated || v.MatchedCanonicalSimple != can {
+			continue
+		}
+		if _, ok := seen[v.DataSourceID]; ok {
+			continue
+		}
+		seen[v.DataSourceID] = struct{}{}
+		res = append(res, v)
+	}
+	return res
+}
+
+// compare groups results by a value of a field, val returns the value for
+// a result with the given index. It returns false if there are less than
+// two different values.
+func compare(
+	field string,
+	rds []*vlib.ResultData,
+	val func(i int) string,
+) (Conflict, bool) {
+	res := Conflict{Field: field}
+	idx := make(map[string]int)
+	for j, rd := range rds {
+		v := val(j)
+		if v == "" {
+			continue
+		}
+		i, ok := idx[v]
+		if !ok {
+			i = len(res.Sides)
+			idx[v] = i
+			res.Sides = append(res.Sides, Side{Value: v})
+		}
+		res.Sides[i].DataSources = append(res.Sides[i].DataSources, rd.DataSourceID)
+		res.Sides[i].DataSourceTitles = append(res.Sides[i].DataSourceTitles,
+			rd.DataSourceTitleShort)
+	}
+	if len(res.Sides) < 2 {
+		return res, false
+	}
+	slices.SortStableFunc(res.Sides, func(a, b Side) int {
+		return cmp.Compare(len(b.DataSources), len(a.DataSources))
+	})
+	return res, true
+}
+
+// rankNames returns names of the classification by their ranks. Only
+// ranks from Ranks are returned.
+func rankNames(rd *vlib.ResultData) map[string]string {
+	res := make(map[string]string)
+	if rd.ClassificationPath == "" || rd.ClassificationRanks == "" {
+		return res
+	}
+	names := strings.Split(rd.ClassificationPath, "|")
+	ranks := strings.Split(rd.ClassificationRanks, "|")
+	if len(names) != len(ranks) {
+		return res
+	}
+	for i := range ranks {
+		rank := strings.ToLower(strings.TrimSpace(ranks[i]))
+		if slices.Contains(Ranks, rank) && names[i] != "" {
+			res[rank] = names[i]
+		}
+	}
+	return res
+}
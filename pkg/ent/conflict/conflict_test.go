@@ -0,0 +1,86 @@
+package conflict_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func result(
+	dsID int,
+	title, current string,
+	status vlib.TaxonomicStatus,
+	path string,
+) *vlib.ResultData {
+	return &vlib.ResultData{
+		DataSourceID:           dsID,
+		DataSourceTitleShort:   title,
+		Curation:               vlib.Curated,
+		MatchedCanonicalSimple: "Aus bus",
+		CurrentCanonicalSimple: current,
+		TaxonomicStatus:        status,
+		ClassificationPath:     path,
+		ClassificationRanks:    "kingdom|family|genus|species",
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+	acc, syn := vlib.AcceptedTaxStatus, vlib.SynonymTaxStatus
+	rds := []*vlib.ResultData{
+		result(1, "CoL", "Aus bus", acc, "Plantae|Aceae|Aus|Aus bus"),
+		result(1, "CoL", "Aus bus", acc, "Plantae|Aceae|Aus|Aus bus"),
+		result(3, "ITIS", "Aus bus", acc, "Plantae|Bceae|Aus|Aus bus"),
+		result(11, "GBIF", "Cus dus", syn, "Plantae|Aceae|Cus|Cus dus"),
+		result(12, "EOL", "Aus bus", vlib.UnknownTaxStatus, ""),
+	}
+	notCurated := result(100, "Other", "Eus fus", syn, "")
+	notCurated.Curation = vlib.NotCurated
+	otherName := result(200, "Another", "Eus fus", syn, "")
+	otherName.MatchedCanonicalSimple = "Aus bos"
+	rds = append(rds, notCurated, otherName)
+
+	res := conflict.New(rds)
+	assert.False(res.IsEmpty())
+	assert.Equal(4, res.DataSourcesNum)
+	assert.Equal([]int{1, 3, 11, 12}, res.DataSources)
+
+	var fields []string
+	for _, v := range res.Conflicts {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal([]string{"taxonomicStatus", "currentName", "family", "genus"},
+		fields)
+
+	status := res.Conflicts[0]
+	assert.Equal("Accepted", status.Sides[0].Value)
+	assert.Equal([]int{1, 3}, status.Sides[0].DataSources)
+	assert.Equal([]string{"CoL", "ITIS"}, status.Sides[0].DataSourceTitles)
+	assert.Equal("Synonym", status.Sides[1].Value)
+	assert.Equal([]int{11}, status.Sides[1].DataSources)
+
+	current := res.Conflicts[1]
+	assert.Equal([]int{1, 3, 12}, current.Sides[0].DataSources)
+
+	family := res.Conflicts[2]
+	assert.Equal("Aceae", family.Sides[0].Value)
+	assert.Equal([]int{1, 11}, family.Sides[0].DataSources)
+}
+
+func TestNewAgreement(t *testing.T) {
+	assert := assert.New(t)
+	acc := vlib.AcceptedTaxStatus
+	rds := []*vlib.ResultData{
+		result(1, "CoL", "Aus bus", acc, "Plantae|Aceae|Aus|Aus bus"),
+		result(3, "ITIS", "Aus bus", acc, "Plantae|Aceae|Aus|Aus bus"),
+	}
+	res := conflict.New(rds)
+	assert.True(res.IsEmpty())
+	assert.Equal(2, res.DataSourcesNum)
+
+	res = conflict.New(nil)
+	assert.True(res.IsEmpty())
+	assert.Equal(0, res.DataSourcesNum)
+}
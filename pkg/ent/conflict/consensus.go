@@ -0,0 +1,152 @@
+package conflict
+
+import (
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Consensus is an aggregated opinion of curated data-sources about a name.
+// Opinions of data-sources are weighted by their curation level, the same
+// way as in the score of matched results: the Catalogue of Life has
+// weight 1, curated data-sources 2/3 and auto-curated ones 1/3.
+type Consensus struct {
+	// CurrentName is the currently accepted name most data-sources point
+	// to.
+	CurrentName string `json:"currentName,omitempty"`
+
+	// CurrentCanonical is the simple canonical form of the CurrentName.
+	CurrentCanonical string `json:"currentCanonical,omitempty"`
+
+	// Support is the weighted share of data-sources that point to the
+	// CurrentName, from 0 to 1. It can be used as a sort key, names with
+	// higher support have more reliable consensus.
+	Support float64 `json:"support"`
+
+	// AcceptedShare is the share of data-sources with known taxonomic
+	// status that treat the name as accepted, from 0 to 1.
+	AcceptedShare float64 `json:"acceptedShare"`
+
+	// Class is the class most data-sources place the name into.
+	Class string `json:"class,omitempty"`
+
+	// Order is the order most data-sources place the name into.
+	Order string `json:"order,omitempty"`
+
+	// Family is the family most data-sources place the name into.
+	Family string `json:"family,omitempty"`
+
+	// DataSourcesNum is the number of data-sources that contributed to
+	// the consensus.
+	DataSourcesNum int `json:"dataSourcesNum"`
+}
+
+// NewConsensus finds the consensus of data-sources. Results are selected
+// the same way as in New, and are expected to be sorted and scored, so
+// curation scores are known. Ties go to the data-source with the better
+// scored result.
+func NewConsensus(rds []*vlib.ResultData) Consensus {
+	var res Consensus
+	rds = compared(rds)
+	if len(rds) == 0 {
+		return res
+	}
+	res.DataSourcesNum = len(rds)
+
+	var total float64
+	var accepted, known int
+	weights := make([]float64, len(rds))
+	for i, rd := range rds {
+		weights[i] = weight(rd)
+		total += weights[i]
+		switch rd.TaxonomicStatus {
+		case vlib.AcceptedTaxStatus:
+			accepted++
+			known++
+		case vlib.SynonymTaxStatus:
+			known++
+		}
+	}
+	if known > 0 {
+		res.AcceptedShare = float64(accepted) / float64(known)
+	}
+
+	i, w := vote(rds, weights, func(i int) string {
+		return rds[i].CurrentCanonicalSimple
+	})
+	if i >= 0 {
+		res.CurrentName = rds[i].CurrentName
+		res.CurrentCanonical = rds[i].CurrentCanonicalSimple
+		if total > 0 {
+			res.Support = w / total
+		}
+	}
+
+	ranks := make([]map[string]string, len(rds))
+	for i := range rds {
+		ranks[i] = rankNames(rds[i])
+	}
+	for _, v := range []struct {
+		rank string
+		val  *string
+	}{
+		{"class", &res.Class},
+		{"order", &res.Order},
+		{"family", &res.Family},
+	} {
+		if i, _ := vote(rds, weights, func(i int) string {
+			return ranks[i][v.rank]
+		}); i >= 0 {
+			*v.val = ranks[i][v.rank]
+		}
+	}
+	return res
+}
+
+// weight returns the weight of a data-source opinion. It uses the curation
+// score of the result.
+func weight(rd *vlib.ResultData) float64 {
+	res := float64(rd.ScoreDetails.CuratedDataScore)
+	if res > 0 {
+		return res
+	}
+	// results were not scored
+	switch {
+	case rd.DataSourceID == 1:
+		return 1
+	case rd.Curation == vlib.Curated:
+		return 2.0 / 3
+	default:
+		return 1.0 / 3
+	}
+}
+
+// vote returns the index of the first result with the value that has the
+// largest weight, and the weight of the value. Empty values do not vote.
+// The index is -1 if there are no values.
+func vote(
+	rds []*vlib.ResultData,
+	weights []float64,
+	val func(i int) string,
+) (int, float64) {
+	first := make(map[string]int)
+	sums := make(map[string]float64)
+	var vals []string
+	for i := range rds {
+		v := val(i)
+		if v == "" {
+			continue
+		}
+		if _, ok := first[v]; !ok {
+			first[v] = i
+			vals = append(vals, v)
+		}
+		sums[v] += weights[i]
+	}
+
+	res, best := -1, 0.0
+	for _, v := range vals {
+		if res == -1 || sums[v] > best {
+			res, best = first[v], sums[v]
+		}
+	}
+	return res, best
+}
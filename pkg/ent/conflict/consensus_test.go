@@ -0,0 +1,47 @@
+package conflict_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConsensus(t *testing.T) {
+	assert := assert.New(t)
+	acc, syn := vlib.AcceptedTaxStatus, vlib.SynonymTaxStatus
+	path := "Plantae|Aceae|Aus|Aus bus"
+	rds := []*vlib.ResultData{
+		result(1, "CoL", "Aus bus", acc, path),
+		result(3, "ITIS", "Cus dus", syn, "Plantae|Bceae|Cus|Cus dus"),
+		result(11, "GBIF", "Cus dus", syn, "Plantae|Bceae|Cus|Cus dus"),
+		result(12, "EOL", "Aus bus", vlib.UnknownTaxStatus, ""),
+	}
+	rds[0].ScoreDetails.CuratedDataScore = 1
+	rds[0].CurrentName = "Aus bus L."
+	rds[1].ScoreDetails.CuratedDataScore = 1.0 / 3
+	rds[2].ScoreDetails.CuratedDataScore = 1.0 / 3
+	rds[3].ScoreDetails.CuratedDataScore = 1.0 / 3
+
+	res := conflict.NewConsensus(rds)
+	assert.Equal(4, res.DataSourcesNum)
+	assert.Equal("Aus bus L.", res.CurrentName)
+	assert.Equal("Aus bus", res.CurrentCanonical)
+	assert.InDelta(4.0/6, res.Support, 0.001)
+	assert.InDelta(1.0/3, res.AcceptedShare, 0.001)
+	assert.Equal("Aceae", res.Family)
+
+	// without curation scores ties go to the better result
+	for _, v := range rds {
+		v.ScoreDetails.CuratedDataScore = 0
+	}
+	rds[0].DataSourceID = 5
+	res = conflict.NewConsensus(rds[:2])
+	assert.Equal("Aus bus", res.CurrentCanonical)
+	assert.Equal(0.5, res.Support)
+
+	res = conflict.NewConsensus(nil)
+	assert.Equal(0, res.DataSourcesNum)
+	assert.Empty(res.CurrentName)
+}
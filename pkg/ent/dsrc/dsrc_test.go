@@ -0,0 +1,141 @@
+package dsrc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+var dss = []*vlib.DataSource{
+	{
+		ID:           1,
+		UUID:         "d4df2968-4257-4ad9-ab81-bedbbfb25e2a",
+		Title:        "Catalogue of Life Checklist",
+		TitleShort:   "Catalogue of Life",
+		Curation:     vlib.Curated,
+		HasTaxonData: true,
+	},
+	{
+		ID:          12,
+		Title:       "Encyclopedia of Life",
+		TitleShort:  "EOL",
+		Description: "Global access to knowledge about life on Earth",
+		Curation:    vlib.AutoCurated,
+	},
+	{
+		ID:         169,
+		TitleShort: "uBio NameBank",
+		Citation:   "uBio project",
+		Curation:   vlib.NotCurated,
+	},
+}
+
+func TestFind(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		msg, id string
+		found   bool
+		res     int
+	}{
+		{"id", "12", true, 12},
+		{"unknown id", "13", false, 0},
+		{"uuid", "d4df2968-4257-4ad9-ab81-bedbbfb25e2a", true, 1},
+		{"unknown uuid", "bc3be2bc-9226-4b65-abcc-ffa5ad2c9b6d", false, 0},
+		{"title", "catalogue of life", true, 1},
+		{"title spaces", " eol ", true, 12},
+		{"unknown title", "gbif", false, 0},
+		{"empty", "", false, 0},
+	}
+	for _, v := range tests {
+		ds, ok := dsrc.Find(dss, v.id)
+		assert.Equal(v.found, ok, v.msg)
+		if ok {
+			assert.Equal(v.res, ds.ID, v.msg)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	assert := assert.New(t)
+	yes := true
+	tests := []struct {
+		msg string
+		f   dsrc.Filter
+		res []int
+	}{
+		{"empty", dsrc.Filter{}, []int{1, 12, 169}},
+		{"title", dsrc.Filter{Text: "LIFE"}, []int{1, 12}},
+		{"description", dsrc.Filter{Text: "earth"}, []int{12}},
+		{"citation", dsrc.Filter{Text: "ubio project"}, []int{169}},
+		{"curation", dsrc.Filter{
+			Curation: []vlib.CurationLevel{vlib.Curated, vlib.NotCurated},
+		}, []int{1, 169}},
+		{"taxon data", dsrc.Filter{HasTaxonData: &yes}, []int{1}},
+		{"all", dsrc.Filter{Text: "life", HasTaxonData: &yes,
+			Curation: []vlib.CurationLevel{vlib.AutoCurated}}, []int{}},
+	}
+	for _, v := range tests {
+		res := v.f.Apply(dss)
+		ids := make([]int, len(res))
+		for i := range res {
+			ids[i] = res[i].ID
+		}
+		assert.Equal(v.res, ids, v.msg)
+	}
+}
+
+func TestParseCuration(t *testing.T) {
+	assert := assert.New(t)
+	cl, ok := dsrc.ParseCuration("autoCurated")
+	assert.True(ok)
+	assert.Equal(vlib.AutoCurated, cl)
+	_, ok = dsrc.ParseCuration("somewhat")
+	assert.False(ok)
+}
+
+func TestIdentifiersJSON(t *testing.T) {
+	assert := assert.New(t)
+	var ids dsrc.Identifiers
+	err := json.Unmarshal([]byte(`[1, "EOL", "d4df2968-4257-4ad9-ab81-bedbbfb25e2a"]`), &ids)
+	assert.Nil(err)
+	assert.Equal(
+		dsrc.Identifiers{"1", "EOL", "d4df2968-4257-4ad9-ab81-bedbbfb25e2a"},
+		ids,
+	)
+	err = json.Unmarshal([]byte(`[true]`), &ids)
+	assert.NotNil(err)
+}
+
+func TestRegistry(t *testing.T) {
+	assert := assert.New(t)
+	reg := dsrc.NewRegistry(dss)
+	ds, ok := reg.Get(12)
+	assert.True(ok)
+	assert.Equal("EOL", ds.TitleShort)
+	_, ok = reg.Get(2)
+	assert.False(ok)
+	assert.Len(reg.List(), 3)
+
+	changes := reg.Replace(dss)
+	assert.True(changes.IsEmpty())
+
+	eol := *dss[1]
+	eol.OutlinkURL = "https://eol.org/pages/{}"
+	changes = reg.Replace([]*vlib.DataSource{
+		dss[0], &eol, {ID: 200, TitleShort: "New"},
+	})
+	assert.Equal([]int{200}, changes.Added)
+	assert.Equal([]int{169}, changes.Removed)
+	assert.Equal([]int{12}, changes.Updated)
+
+	ds, _ = reg.Get(12)
+	assert.Equal("https://eol.org/pages/{}", ds.OutlinkURL)
+	ids := make([]int, 0, 3)
+	for _, v := range reg.List() {
+		ids = append(ids, v.ID)
+	}
+	assert.Equal([]int{1, 12, 200}, ids)
+}
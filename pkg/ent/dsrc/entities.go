@@ -0,0 +1,60 @@
+// Package dsrc contains entities that describe data-sources and their
+// content.
+package dsrc
+
+import "errors"
+
+// ErrNotFound is returned when a requested data-source does not exist.
+var ErrNotFound = errors.New("data-source not found")
+
+// Stats contains figures computed from the records of a data-source. They
+// help to decide which data-sources to use for verification.
+type Stats struct {
+	// DataSourceID is the ID of the data-source.
+	DataSourceID int `json:"dataSourceId"`
+
+	// TitleShort is a short title of the data-source.
+	TitleShort string `json:"titleShort"`
+
+	// RecordsNum is the number of name-string records in the data-source.
+	RecordsNum int `json:"recordsNum"`
+
+	// NameStringsNum is the number of unique name-strings.
+	NameStringsNum int `json:"nameStringsNum"`
+
+	// ClassificationPercentage is the share of records that have a
+	// classification.
+	ClassificationPercentage float64 `json:"classificationPercentage"`
+
+	// AcceptedNum is the number of records with currently accepted names.
+	AcceptedNum int `json:"acceptedNum"`
+
+	// SynonymsNum is the number of records with synonyms.
+	SynonymsNum int `json:"synonymsNum"`
+
+	// VernacularRecordsNum is the number of records that have vernacular
+	// names.
+	VernacularRecordsNum int `json:"vernacularRecordsNum"`
+
+	// Vernaculars contain numbers of vernacular names by language code.
+	Vernaculars []Count `json:"vernaculars,omitempty"`
+
+	// Ranks contain numbers of records by their rank.
+	Ranks []Count `json:"ranks,omitempty"`
+
+	// Kingdoms contain numbers of records by kingdoms of their
+	// classification.
+	Kingdoms []Count `json:"kingdoms,omitempty"`
+
+	// ComputedAt is the time when the statistics were computed.
+	ComputedAt string `json:"computedAt"`
+}
+
+// Count is the number of items that belong to a category.
+type Count struct {
+	// Name of the category, for example a rank, or a language code.
+	Name string `json:"name"`
+
+	// Count is the number of items in the category.
+	Count int `json:"count"`
+}
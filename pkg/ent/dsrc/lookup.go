@@ -0,0 +1,138 @@
+package dsrc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/google/uuid"
+)
+
+// Identifiers are references to data-sources. An identifier can be a
+// numeric ID, a UUID or a short title of a data-source. In JSON
+// identifiers can be given as numbers or as strings.
+type Identifiers []string
+
+// UnmarshalJSON allows to mix numbers and strings in identifiers.
+func (ids *Identifiers) UnmarshalJSON(bs []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(bs, &raw); err != nil {
+		return fmt.Errorf("dsrc.UnmarshalJSON: %w", err)
+	}
+	res := make(Identifiers, len(raw))
+	for i, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			res[i] = s
+			continue
+		}
+		var id int
+		if err := json.Unmarshal(v, &id); err != nil {
+			return fmt.Errorf("dsrc.UnmarshalJSON: wrong identifier %s", v)
+		}
+		res[i] = strconv.Itoa(id)
+	}
+	*ids = res
+	return nil
+}
+
+// Find returns a data-source that corresponds to the identifier. The
+// identifier is compared to IDs, UUIDs, and, case-insensitively, to
+// short titles of data-sources.
+func Find(dss []*vlib.DataSource, id string) (*vlib.DataSource, bool) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, false
+	}
+	if i, err := strconv.Atoi(id); err == nil {
+		for _, ds := range dss {
+			if ds.ID == i {
+				return ds, true
+			}
+		}
+		return nil, false
+	}
+	if u, err := uuid.Parse(id); err == nil {
+		for _, ds := range dss {
+			if ds.UUID == u.String() {
+				return ds, true
+			}
+		}
+		return nil, false
+	}
+	for _, ds := range dss {
+		if strings.EqualFold(ds.TitleShort, id) {
+			return ds, true
+		}
+	}
+	return nil, false
+}
+
+// Filter contains criteria for selecting data-sources. Empty criteria
+// are ignored.
+type Filter struct {
+	// Text is a case-insensitive substring of a title, a short title,
+	// a description or a citation of a data-source.
+	Text string
+
+	// Curation limits data-sources to given curation levels.
+	Curation []vlib.CurationLevel
+
+	// HasTaxonData limits data-sources to ones that have (or do not have)
+	// taxonomic data.
+	HasTaxonData *bool
+}
+
+// Apply returns data-sources that satisfy all criteria of the filter.
+func (f Filter) Apply(dss []*vlib.DataSource) []*vlib.DataSource {
+	text := strings.ToLower(strings.TrimSpace(f.Text))
+	res := make([]*vlib.DataSource, 0, len(dss))
+	for _, ds := range dss {
+		if text != "" && !hasText(ds, text) {
+			continue
+		}
+		if len(f.Curation) > 0 && !hasCuration(ds, f.Curation) {
+			continue
+		}
+		if f.HasTaxonData != nil && ds.HasTaxonData != *f.HasTaxonData {
+			continue
+		}
+		res = append(res, ds)
+	}
+	return res
+}
+
+// ParseCuration converts a string to a curation level. The comparison is
+// case-insensitive, for example `curated` and `AutoCurated` are valid.
+func ParseCuration(s string) (vlib.CurationLevel, bool) {
+	for _, cl := range []vlib.CurationLevel{
+		vlib.NotCurated, vlib.AutoCurated, vlib.Curated,
+	} {
+		if strings.EqualFold(cl.String(), strings.TrimSpace(s)) {
+			return cl, true
+		}
+	}
+	return vlib.NotCurated, false
+}
+
+func hasText(ds *vlib.DataSource, text string) bool {
+	for _, v := range []string{
+		ds.Title, ds.TitleShort, ds.Description, ds.Citation,
+	} {
+		if strings.Contains(strings.ToLower(v), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCuration(ds *vlib.DataSource, cls []vlib.CurationLevel) bool {
+	for _, cl := range cls {
+		if ds.Curation == cl {
+			return true
+		}
+	}
+	return false
+}
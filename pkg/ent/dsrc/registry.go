@@ -0,0 +1,95 @@
+package dsrc
+
+import (
+	"cmp"
+	"slices"
+	"sync/atomic"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Registry keeps metadata of data-sources. One registry is shared by all
+// components of the app. Its content can be replaced at any time, readers
+// always see either the old or the new set of data-sources, never a mix.
+type Registry struct {
+	dsm atomic.Pointer[map[int]*vlib.DataSource]
+}
+
+// Changes describe the difference between the old and the new content of
+// a registry.
+type Changes struct {
+	// Added are IDs of new data-sources.
+	Added []int `json:"added"`
+
+	// Removed are IDs of data-sources that do not exist anymore.
+	Removed []int `json:"removed"`
+
+	// Updated are IDs of data-sources with modified metadata.
+	Updated []int `json:"updated"`
+}
+
+// IsEmpty is true if nothing changed.
+func (c Changes) IsEmpty() bool {
+	return len(c.Added)+len(c.Removed)+len(c.Updated) == 0
+}
+
+// NewRegistry creates a registry from a list of data-sources.
+func NewRegistry(dss []*vlib.DataSource) *Registry {
+	var res Registry
+	res.dsm.Store(toMap(dss))
+	return &res
+}
+
+// Get returns a data-source by its ID.
+func (r *Registry) Get(id int) (*vlib.DataSource, bool) {
+	ds, ok := (*r.dsm.Load())[id]
+	return ds, ok
+}
+
+// List returns all data-sources sorted by their IDs.
+func (r *Registry) List() []*vlib.DataSource {
+	dsm := *r.dsm.Load()
+	res := make([]*vlib.DataSource, 0, len(dsm))
+	for _, ds := range dsm {
+		res = append(res, ds)
+	}
+	slices.SortFunc(res, func(a, b *vlib.DataSource) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return res
+}
+
+// Replace substitutes the content of the registry with new data-sources
+// and returns the changes.
+func (r *Registry) Replace(dss []*vlib.DataSource) Changes {
+	dsm := toMap(dss)
+	old := *r.dsm.Swap(dsm)
+
+	var res Changes
+	for id, ds := range *dsm {
+		oldDS, ok := old[id]
+		switch {
+		case !ok:
+			res.Added = append(res.Added, id)
+		case *oldDS != *ds:
+			res.Updated = append(res.Updated, id)
+		}
+	}
+	for id := range old {
+		if _, ok := (*dsm)[id]; !ok {
+			res.Removed = append(res.Removed, id)
+		}
+	}
+	slices.Sort(res.Added)
+	slices.Sort(res.Removed)
+	slices.Sort(res.Updated)
+	return res
+}
+
+func toMap(dss []*vlib.DataSource) *map[int]*vlib.DataSource {
+	res := make(map[int]*vlib.DataSource, len(dss))
+	for _, ds := range dss {
+		res[ds.ID] = ds
+	}
+	return &res
+}
@@ -0,0 +1,229 @@
+// Package dwca converts results of verification into a Darwin Core Archive
+// (DwC-A) checklist. The archive has Taxon core (taxon.txt), optional
+// Vernacular Names extension (vernacularname.txt), metadata of the
+// archive (meta.xml) and of the dataset (eml.xml). The files are written
+// by the dwcaio package.
+package dwca
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Meta contains data for the metadata of the dataset.
+type Meta struct {
+	// Title of the dataset.
+	Title string
+
+	// Creator is the software that created the archive, for example
+	// `GNames v1.6.1`.
+	Creator string
+
+	// Date is the publication date of the dataset.
+	Date time.Time
+
+	// DataSources are data-sources that provided the records.
+	DataSources []*vlib.DataSource
+}
+
+// Taxon is a row of the Taxon core.
+type Taxon struct {
+	// ID is the identifier of the row, it is the UUID of the verified
+	// name-string.
+	ID string
+
+	TaxonID                string
+	ScientificNameID       string
+	ScientificName         string
+	AcceptedNameUsageID    string
+	AcceptedNameUsage      string
+	TaxonomicStatus        string
+	TaxonRank              string
+	HigherClassification   string
+	Kingdom                string
+	Phylum                 string
+	Class                  string
+	Order                  string
+	Family                 string
+	Genus                  string
+	DatasetID              string
+	DatasetName            string
+	References             string
+	VerbatimIdentification string
+	TaxonRemarks           string
+}
+
+// Vernacular is a row of the Vernacular Names extension.
+type Vernacular struct {
+	// ID is the ID of the core row.
+	ID string
+
+	VernacularName  string
+	Language        string
+	CountryCode     string
+	Locality        string
+	IsPreferredName bool
+}
+
+// Archive contains the data of a Darwin Core Archive.
+type Archive struct {
+	Meta        Meta
+	Taxa        []Taxon
+	Vernaculars []Vernacular
+}
+
+// ranks map ranks of classifications to columns of the Taxon core.
+var ranks = map[string]func(*Taxon) *string{
+	"kingdom": func(t *Taxon) *string { return &t.Kingdom },
+	"phylum":  func(t *Taxon) *string { return &t.Phylum },
+	"class":   func(t *Taxon) *string { return &t.Class },
+	"order":   func(t *Taxon) *string { return &t.Order },
+	"family":  func(t *Taxon) *string { return &t.Family },
+	"genus":   func(t *Taxon) *string { return &t.Genus },
+}
+
+// New creates an archive from the output of verification. Every unique
+// record makes one Taxon row. The row uses the best result of the
+// name, or its top result if all matches were requested. Names without
+// matches are kept with empty taxonomic data, so the checklist is complete.
+// Record IDs are unique only within a data-source, so TaxonID and
+// AcceptedNameUsageID are prefixed with the ID of the data-source, for
+// example `1:3FDQ`. AcceptedNameUsageID is kept only if the accepted
+// record is in the archive, otherwise the accepted name is given by
+// AcceptedNameUsage.
+func New(out verif.Output, meta Meta) Archive {
+	res := Archive{Meta: meta}
+	seen := make(map[string]struct{})
+	taxonIDs := make(map[string]struct{})
+	for _, n := range out.Names {
+		if _, ok := seen[n.ID]; ok {
+			continue
+		}
+		seen[n.ID] = struct{}{}
+
+		tx := newTaxon(n.Name)
+		if tx.TaxonID != "" {
+			// different name-strings can resolve to the same record
+			if _, ok := taxonIDs[tx.TaxonID]; ok {
+				continue
+			}
+			taxonIDs[tx.TaxonID] = struct{}{}
+		}
+		res.Taxa = append(res.Taxa, tx)
+		for _, v := range n.Vernaculars {
+			res.Vernaculars = append(res.Vernaculars, Vernacular{
+				ID:              n.ID,
+				VernacularName:  v.Name,
+				Language:        v.LanguageCode,
+				CountryCode:     strings.Join(v.Countries, ","),
+				Locality:        strings.Join(v.Localities, "; "),
+				IsPreferredName: v.Preferred,
+			})
+		}
+	}
+
+	for i := range res.Taxa {
+		if _, ok := taxonIDs[res.Taxa[i].AcceptedNameUsageID]; !ok {
+			res.Taxa[i].AcceptedNameUsageID = ""
+		}
+	}
+	return res
+}
+
+// DataSourceIDs returns sorted IDs of data-sources that provided records
+// to the archive.
+func DataSourceIDs(out verif.Output) []int {
+	var res []int
+	for i := range out.Names {
+		rd := result(out.Names[i].Name)
+		if rd != nil && !slices.Contains(res, rd.DataSourceID) {
+			res = append(res, rd.DataSourceID)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// result returns the result that is used for the Taxon row of a name.
+func result(n vlib.Name) *vlib.ResultData {
+	if n.BestResult == nil && len(n.Results) > 0 {
+		return n.Results[0]
+	}
+	return n.BestResult
+}
+
+func newTaxon(n vlib.Name) Taxon {
+	res := Taxon{
+		ID:                     n.ID,
+		VerbatimIdentification: n.Name,
+		TaxonRemarks:           "matchType: " + n.MatchType.String(),
+	}
+	rd := result(n)
+	if rd == nil {
+		return res
+	}
+
+	res.TaxonID = taxonID(rd.DataSourceID, rd.RecordID)
+	res.ScientificNameID = rd.GlobalID
+	res.ScientificName = rd.MatchedName
+	res.AcceptedNameUsageID = taxonID(rd.DataSourceID, rd.CurrentRecordID)
+	res.AcceptedNameUsage = rd.CurrentName
+	if rd.TaxonomicStatus != vlib.UnknownTaxStatus {
+		res.TaxonomicStatus = strings.ToLower(rd.TaxonomicStatus.String())
+	}
+	res.DatasetID = strconv.Itoa(rd.DataSourceID)
+	res.DatasetName = rd.DataSourceTitleShort
+	res.References = rd.Outlink
+	res.TaxonRemarks = fmt.Sprintf("matchType: %s; editDistance: %d",
+		rd.MatchType, rd.EditDistance)
+
+	path := splitPipes(rd.ClassificationPath)
+	rs := splitPipes(rd.ClassificationRanks)
+	res.HigherClassification = strings.Join(path, "|")
+	if len(path) != len(rs) {
+		return res
+	}
+	for i, r := range rs {
+		if col, ok := ranks[strings.ToLower(r)]; ok {
+			*col(&res) = path[i]
+		}
+	}
+	// the last element of a classification is the taxon itself
+	if l := len(path); l > 0 && isTaxon(path[l-1], rd) {
+		res.TaxonRank = strings.ToLower(rs[l-1])
+		res.HigherClassification = strings.Join(path[:l-1], "|")
+	}
+	return res
+}
+
+// taxonID creates an identifier of a record that is unique across
+// data-sources.
+func taxonID(dataSourceID int, recordID string) string {
+	if recordID == "" {
+		return ""
+	}
+	return strconv.Itoa(dataSourceID) + ":" + recordID
+}
+
+func isTaxon(name string, rd *vlib.ResultData) bool {
+	return slices.Contains([]string{
+		rd.CurrentCanonicalSimple, rd.CurrentCanonicalFull, rd.CurrentName,
+	}, name)
+}
+
+func splitPipes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	res := strings.Split(s, "|")
+	for i := range res {
+		res[i] = strings.TrimSpace(res[i])
+	}
+	return res
+}
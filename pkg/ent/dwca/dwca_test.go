@@ -0,0 +1,110 @@
+package dwca_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/dwca"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func output() verif.Output {
+	bubo := vlib.Name{
+		ID:        "id1",
+		Name:      "Bubo bubo",
+		MatchType: vlib.Exact,
+		BestResult: &vlib.ResultData{
+			DataSourceID:           1,
+			DataSourceTitleShort:   "Catalogue of Life",
+			RecordID:               "3FDQ",
+			MatchedName:            "Bubo bubo (Linnaeus, 1758)",
+			CurrentRecordID:        "3FDQ",
+			CurrentName:            "Bubo bubo (Linnaeus, 1758)",
+			CurrentCanonicalSimple: "Bubo bubo",
+			TaxonomicStatus:        vlib.AcceptedTaxStatus,
+			ClassificationPath:     "Animalia|Chordata|Aves|Strigiformes|Strigidae|Bubo|Bubo bubo",
+			ClassificationRanks:    "kingdom|phylum|class|order|family|genus|species",
+			MatchType:              vlib.Exact,
+		},
+	}
+	return verif.Output{Names: []verif.Name{
+		{
+			Name: bubo,
+			Vernaculars: []vern.Vernacular{
+				{Name: "Eurasian eagle-owl", LanguageCode: "eng", Preferred: true},
+			},
+		},
+		{Name: bubo},
+		{Name: vlib.Name{ID: "id2", Name: "Nothing here", MatchType: vlib.NoMatch}},
+	}}
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+	a := dwca.New(output(), dwca.Meta{})
+	require.Len(t, a.Taxa, 2)
+	tx := a.Taxa[0]
+	assert.Equal("id1", tx.ID)
+	assert.Equal("1:3FDQ", tx.TaxonID)
+	assert.Equal("Bubo bubo (Linnaeus, 1758)", tx.ScientificName)
+	assert.Equal("accepted", tx.TaxonomicStatus)
+	assert.Equal("species", tx.TaxonRank)
+	assert.Equal("Animalia|Chordata|Aves|Strigiformes|Strigidae|Bubo",
+		tx.HigherClassification)
+	assert.Equal("Animalia", tx.Kingdom)
+	assert.Equal("Strigiformes", tx.Order)
+	assert.Equal("Bubo", tx.Genus)
+	assert.Equal("1", tx.DatasetID)
+	assert.Equal("Bubo bubo", tx.VerbatimIdentification)
+
+	tx = a.Taxa[1]
+	assert.Equal("Nothing here", tx.VerbatimIdentification)
+	assert.Empty(tx.ScientificName)
+	assert.Equal("matchType: NoMatch", tx.TaxonRemarks)
+
+	assert.Equal([]int{1}, dwca.DataSourceIDs(output()))
+
+	require.Len(t, a.Vernaculars, 1)
+	assert.Equal("id1", a.Vernaculars[0].ID)
+	assert.True(a.Vernaculars[0].IsPreferredName)
+}
+
+func TestAcceptedNameUsageID(t *testing.T) {
+	assert := assert.New(t)
+	out := output()
+	synonym := func(id, recordID, currentID string, dsID int) verif.Name {
+		return verif.Name{Name: vlib.Name{
+			ID:        id,
+			Name:      "Strix bubo",
+			MatchType: vlib.Exact,
+			BestResult: &vlib.ResultData{
+				DataSourceID:    dsID,
+				RecordID:        recordID,
+				MatchedName:     "Strix bubo Linnaeus, 1758",
+				CurrentRecordID: currentID,
+				CurrentName:     "Bubo bubo (Linnaeus, 1758)",
+				TaxonomicStatus: vlib.SynonymTaxStatus,
+			},
+		}}
+	}
+	out.Names = append(out.Names,
+		synonym("id3", "SYN1", "3FDQ", 1),
+		synonym("id4", "SYN2", "NOT_IN_ARCHIVE", 1),
+		// the same record ID in another data-source
+		synonym("id5", "SYN3", "3FDQ", 11),
+		// another name-string of the record SYN1
+		synonym("id6", "SYN1", "3FDQ", 1),
+	)
+	a := dwca.New(out, dwca.Meta{})
+	require.Len(t, a.Taxa, 5)
+	assert.Equal("1:3FDQ", a.Taxa[0].AcceptedNameUsageID)
+	assert.Equal("1:SYN1", a.Taxa[2].TaxonID)
+	assert.Equal("1:3FDQ", a.Taxa[2].AcceptedNameUsageID)
+	assert.Empty(a.Taxa[3].AcceptedNameUsageID)
+	assert.Equal("Bubo bubo (Linnaeus, 1758)", a.Taxa[3].AcceptedNameUsage)
+	assert.Equal("11:SYN3", a.Taxa[4].TaxonID)
+	assert.Empty(a.Taxa[4].AcceptedNameUsageID)
+}
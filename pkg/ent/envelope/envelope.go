@@ -0,0 +1,153 @@
+// Package envelope contains entities of the v2 API responses. Every
+// response has the same shape: metadata, data and errors.
+package envelope
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// APIVersion is the version of the API that uses the envelope.
+const APIVersion = "v2"
+
+const (
+	// DefaultLimit is the number of items on a page, if the limit is not
+	// given.
+	DefaultLimit = 100
+
+	// MaxLimit is the largest allowed number of items on a page.
+	MaxLimit = 1000
+)
+
+// cursorPrefix marks cursors created by the service.
+const cursorPrefix = "o:"
+
+// ErrCursor is returned for cursors that were not created by the service.
+var ErrCursor = errors.New("invalid cursor")
+
+// Response is a response of the v2 API. Data is empty if there are errors.
+type Response[T any] struct {
+	// Meta contains metadata of the response.
+	Meta Meta `json:"meta"`
+
+	// Data is the payload of the response.
+	Data T `json:"data"`
+
+	// Errors describe problems that prevented a successful response.
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Meta contains metadata of a response.
+type Meta struct {
+	// APIVersion is the version of the API.
+	APIVersion string `json:"apiVersion"`
+
+	// Page describes the current page of list endpoints.
+	Page *Page `json:"page,omitempty"`
+
+	// Details contain metadata provided by a particular endpoint, for
+	// example input parameters of verification and its statistics.
+	Details any `json:"details,omitempty"`
+}
+
+// Page describes a page of results of a list endpoint.
+type Page struct {
+	// Limit is the largest number of items on the page.
+	Limit int `json:"limit"`
+
+	// Total is the number of items in all pages.
+	Total int `json:"total"`
+
+	// NextCursor has to be sent as the `cursor` parameter to get the next
+	// page. It is an encoded offset of the first item of the next page,
+	// not a position in the data, so if the data change between requests
+	// items can be skipped or repeated. It is empty for the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Error describes a problem with a request.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Code is a machine-readable code of the error, for example
+	// `invalid_parameter` or `not_found`.
+	Code string `json:"code"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+
+	// Parameter is the name of the request parameter that caused the error.
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// New creates a response with data and optional endpoint metadata.
+func New[T any](data T, details any) Response[T] {
+	return Response[T]{
+		Meta: Meta{APIVersion: APIVersion, Details: details},
+		Data: data,
+	}
+}
+
+// NewError creates a response with errors and no data.
+func NewError(errs ...Error) Response[any] {
+	return Response[any]{
+		Meta:   Meta{APIVersion: APIVersion},
+		Errors: errs,
+	}
+}
+
+// Paginate returns the page of items that starts at the cursor. An empty
+// cursor means the first page. The limit is set to DefaultLimit if it is
+// not positive, and to MaxLimit if it is larger than MaxLimit.
+func Paginate[T any](items []T, cursor string, limit int) ([]T, Page, error) {
+	offset, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, Page{}, err
+	}
+	limit = max(limit, 0)
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	page := Page{Limit: limit, Total: len(items)}
+	if offset >= len(items) {
+		return []T{}, page, nil
+	}
+	end := min(offset+limit, len(items))
+	if end < len(items) {
+		page.NextCursor = EncodeCursor(end)
+	}
+	return items[offset:end], page, nil
+}
+
+// EncodeCursor creates an opaque cursor from an offset. The encoding only
+// hides the offset from clients, it does not make the cursor stable.
+func EncodeCursor(offset int) string {
+	s := cursorPrefix + strconv.Itoa(offset)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeCursor returns the offset of a cursor. An empty cursor has zero
+// offset.
+func DecodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	bs, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrCursor
+	}
+	s, ok := strings.CutPrefix(string(bs), cursorPrefix)
+	if !ok {
+		return 0, ErrCursor
+	}
+	res, err := strconv.Atoi(s)
+	if err != nil || res < 0 {
+		return 0, ErrCursor
+	}
+	return res, nil
+}
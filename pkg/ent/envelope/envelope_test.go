@@ -0,0 +1,67 @@
+package envelope_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/envelope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	assert := assert.New(t)
+	for _, v := range []int{0, 1, 100, 123456} {
+		res, err := envelope.DecodeCursor(envelope.EncodeCursor(v))
+		assert.Nil(err)
+		assert.Equal(v, res)
+	}
+
+	res, err := envelope.DecodeCursor("")
+	assert.Nil(err)
+	assert.Equal(0, res)
+
+	for _, v := range []string{"!!!", "MTA", "bzotMQ"} {
+		_, err = envelope.DecodeCursor(v)
+		assert.ErrorIs(err, envelope.ErrCursor, v)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	assert := assert.New(t)
+	items := make([]int, 250)
+	for i := range items {
+		items[i] = i
+	}
+
+	res, page, err := envelope.Paginate(items, "", 0)
+	assert.Nil(err)
+	assert.Len(res, envelope.DefaultLimit)
+	assert.Equal(250, page.Total)
+	assert.Equal(envelope.DefaultLimit, page.Limit)
+	assert.NotEmpty(page.NextCursor)
+
+	var all []int
+	var cursor string
+	for {
+		res, page, err = envelope.Paginate(items, cursor, 100)
+		assert.Nil(err)
+		all = append(all, res...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(items, all)
+
+	res, page, err = envelope.Paginate(items, "", 5000)
+	assert.Nil(err)
+	assert.Len(res, 250)
+	assert.Equal(envelope.MaxLimit, page.Limit)
+	assert.Empty(page.NextCursor)
+
+	res, _, err = envelope.Paginate(items, envelope.EncodeCursor(300), 10)
+	assert.Nil(err)
+	assert.Empty(res)
+
+	_, _, err = envelope.Paginate(items, "bad", 10)
+	assert.ErrorIs(err, envelope.ErrCursor)
+}
@@ -0,0 +1,155 @@
+// Package flat converts results of verification into flat records. The
+// records are used for CSV, TSV and XML outputs, that are more convenient
+// for spreadsheets than nested JSON.
+package flat
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/gnames/gnfmt"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Kind describes which result of a name is in a record.
+type Kind string
+
+const (
+	// BestMatch is a record with the best result of a name.
+	BestMatch Kind = "BestMatch"
+
+	// Match is a record with one of all results of a name.
+	Match Kind = "Match"
+
+	// NoMatch is a record of a name without results.
+	NoMatch Kind = "NoMatch"
+)
+
+// Header contains names of the columns of CSV and TSV outputs. The order
+// of columns is stable, new columns can only be appended.
+var Header = []string{
+	"Kind",
+	"Index",
+	"ID",
+	"Name",
+	"Cardinality",
+	"MatchType",
+	"Curation",
+	"SortScore",
+	"DataSourceID",
+	"DataSourceTitleShort",
+	"RecordID",
+	"GlobalID",
+	"LocalID",
+	"Outlink",
+	"MatchedNameID",
+	"MatchedName",
+	"MatchedCanonical",
+	"MatchedCardinality",
+	"EditDistance",
+	"StemEditDistance",
+	"CurrentRecordID",
+	"CurrentNameID",
+	"CurrentName",
+	"CurrentCanonical",
+	"TaxonomicStatus",
+	"ClassificationPath",
+	"ClassificationRanks",
+	"ClassificationIDs",
+	"Error",
+}
+
+// Record is a name-string together with one of its results.
+type Record struct {
+	// Kind tells if the record contains the best result, one of all
+	// results or no result at all.
+	Kind Kind
+
+	// Index is the position of the name-string in the input.
+	Index int
+
+	// Name is the verified name-string.
+	Name *vlib.Name
+
+	// Result is nil for names without matches.
+	Result *vlib.ResultData
+}
+
+// Records flattens names. If allMatches is true, every result of a name
+// makes a record, otherwise only the best result is used. Names without
+// results make one record each.
+func Records(names []vlib.Name, allMatches bool) []Record {
+	res := make([]Record, 0, len(names))
+	for i := range names {
+		n := &names[i]
+		switch {
+		case allMatches && len(n.Results) > 0:
+			for _, rd := range n.Results {
+				res = append(res, Record{Kind: Match, Index: i, Name: n, Result: rd})
+			}
+		case !allMatches && n.BestResult != nil:
+			res = append(res,
+				Record{Kind: BestMatch, Index: i, Name: n, Result: n.BestResult},
+			)
+		default:
+			res = append(res, Record{Kind: NoMatch, Index: i, Name: n})
+		}
+	}
+	return res
+}
+
+// Row returns values of a record in the order of the Header.
+func (r Record) Row() []string {
+	n := r.Name
+	res := []string{
+		string(r.Kind),
+		strconv.Itoa(r.Index),
+		n.ID,
+		n.Name,
+		strconv.Itoa(n.Cardinality),
+		n.MatchType.String(),
+		n.Curation.String(),
+	}
+	rd := r.Result
+	if rd == nil {
+		res = gnfmt.NormRowSize(res, len(Header)-1)
+		return append(res, n.Error)
+	}
+	return append(res,
+		strconv.FormatFloat(rd.SortScore, 'f', -1, 64),
+		strconv.Itoa(rd.DataSourceID),
+		rd.DataSourceTitleShort,
+		rd.RecordID,
+		rd.GlobalID,
+		rd.LocalID,
+		rd.Outlink,
+		rd.MatchedNameID,
+		rd.MatchedName,
+		rd.MatchedCanonicalFull,
+		strconv.Itoa(rd.MatchedCardinality),
+		strconv.Itoa(rd.EditDistance),
+		strconv.Itoa(rd.StemEditDistance),
+		rd.CurrentRecordID,
+		rd.CurrentNameID,
+		rd.CurrentName,
+		rd.CurrentCanonicalFull,
+		rd.TaxonomicStatus.String(),
+		rd.ClassificationPath,
+		rd.ClassificationRanks,
+		rd.ClassificationIDs,
+		n.Error,
+	)
+}
+
+// CSV returns records as CSV (sep is ',') or TSV (sep is '\t') with the
+// header in the first row.
+func CSV(recs []Record, sep rune) []byte {
+	var b bytes.Buffer
+	b.WriteString(gnfmt.ToCSV(Header, sep))
+	b.WriteByte('\n')
+	for _, r := range recs {
+		b.WriteString(gnfmt.ToCSV(r.Row(), sep))
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,100 @@
+package flat_test
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/flat"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func names() []vlib.Name {
+	best := &vlib.ResultData{
+		DataSourceID:         1,
+		DataSourceTitleShort: "Catalogue of Life",
+		RecordID:             "3FDQ",
+		MatchedName:          "Bubo bubo (Linnaeus, 1758)",
+		MatchedCanonicalFull: "Bubo bubo",
+		CurrentName:          "Bubo bubo (Linnaeus, 1758)",
+		TaxonomicStatus:      vlib.AcceptedTaxStatus,
+		ClassificationPath:   "Animalia|Chordata|Aves",
+		SortScore:            9.5,
+	}
+	other := &vlib.ResultData{
+		DataSourceID:    11,
+		MatchedName:     "Bubo bubo, \"eagle owl\"",
+		TaxonomicStatus: vlib.SynonymTaxStatus,
+	}
+	return []vlib.Name{
+		{
+			ID:         "id1",
+			Name:       "Bubo bubo",
+			MatchType:  vlib.Exact,
+			BestResult: best,
+			Results:    []*vlib.ResultData{best, other},
+		},
+		{ID: "id2", Name: "Nothing here", MatchType: vlib.NoMatch},
+	}
+}
+
+func TestRecords(t *testing.T) {
+	assert := assert.New(t)
+	recs := flat.Records(names(), false)
+	assert.Len(recs, 2)
+	assert.Equal(flat.BestMatch, recs[0].Kind)
+	assert.Equal(flat.NoMatch, recs[1].Kind)
+	assert.Equal(1, recs[1].Index)
+
+	recs = flat.Records(names(), true)
+	assert.Len(recs, 3)
+	assert.Equal(flat.Match, recs[0].Kind)
+	assert.Equal(11, recs[1].Result.DataSourceID)
+	assert.Equal(0, recs[1].Index)
+	assert.Equal(flat.NoMatch, recs[2].Kind)
+
+	for _, r := range recs {
+		assert.Len(r.Row(), len(flat.Header))
+	}
+}
+
+func TestCSV(t *testing.T) {
+	assert := assert.New(t)
+	for _, sep := range []rune{',', '\t'} {
+		out := flat.CSV(flat.Records(names(), true), sep)
+		r := csv.NewReader(strings.NewReader(string(out)))
+		r.Comma = sep
+		rows, err := r.ReadAll()
+		assert.Nil(err)
+		assert.Len(rows, 4)
+		assert.Equal(flat.Header, rows[0])
+		assert.Equal("Bubo bubo (Linnaeus, 1758)", rows[1][15])
+		assert.Equal("Bubo bubo, \"eagle owl\"", rows[2][15])
+		assert.Equal("Synonym", rows[2][24])
+		assert.Equal("NoMatch", rows[3][0])
+		assert.Equal("Nothing here", rows[3][3])
+	}
+}
+
+func TestXML(t *testing.T) {
+	assert := assert.New(t)
+	out, err := flat.XML(flat.Records(names(), false))
+	assert.Nil(err)
+	s := string(out)
+	assert.True(strings.HasPrefix(s, xml.Header))
+	assert.Contains(s, `xmlns:dwc="http://rs.tdwg.org/dwc/terms/"`)
+	assert.Contains(s, `<record kind="BestMatch" index="0">`)
+	assert.Contains(s, "<dwc:scientificName>Bubo bubo (Linnaeus, 1758)</dwc:scientificName>")
+	assert.Contains(s, "<dwc:taxonomicStatus>Accepted</dwc:taxonomicStatus>")
+	assert.Contains(s, `<record kind="NoMatch" index="1">`)
+
+	var doc struct {
+		Records []struct {
+			Kind string `xml:"kind,attr"`
+		} `xml:"record"`
+	}
+	assert.Nil(xml.Unmarshal(out, &doc))
+	assert.Len(doc.Records, 2)
+}
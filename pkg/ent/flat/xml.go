@@ -0,0 +1,96 @@
+package flat
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// Namespaces of XML output.
+const (
+	nsDwC     = "http://rs.tdwg.org/dwc/terms/"
+	nsDCTerms = "http://purl.org/dc/terms/"
+	nsGN      = "https://globalnames.org/terms/"
+)
+
+// xmlRecords is the root element of XML output.
+type xmlRecords struct {
+	XMLName   xml.Name    `xml:"records"`
+	NSDwC     string      `xml:"xmlns:dwc,attr"`
+	NSDCTerms string      `xml:"xmlns:dcterms,attr"`
+	NSGN      string      `xml:"xmlns:gn,attr"`
+	Records   []xmlRecord `xml:"record"`
+}
+
+// xmlRecord is a record that uses Darwin Core terms where they exist,
+// and `gn` terms for verification details.
+type xmlRecord struct {
+	Kind                 Kind   `xml:"kind,attr"`
+	Index                int    `xml:"index,attr"`
+	NameID               string `xml:"gn:nameID"`
+	VerbatimName         string `xml:"gn:verbatimName"`
+	MatchType            string `xml:"gn:matchType"`
+	Curation             string `xml:"gn:curation"`
+	SortScore            string `xml:"gn:sortScore,omitempty"`
+	EditDistance         string `xml:"gn:editDistance,omitempty"`
+	DatasetID            string `xml:"dwc:datasetID,omitempty"`
+	DatasetName          string `xml:"dwc:datasetName,omitempty"`
+	TaxonID              string `xml:"dwc:taxonID,omitempty"`
+	ScientificNameID     string `xml:"dwc:scientificNameID,omitempty"`
+	References           string `xml:"dcterms:references,omitempty"`
+	ScientificName       string `xml:"dwc:scientificName,omitempty"`
+	CanonicalName        string `xml:"gn:canonicalName,omitempty"`
+	AcceptedNameUsageID  string `xml:"dwc:acceptedNameUsageID,omitempty"`
+	AcceptedNameUsage    string `xml:"dwc:acceptedNameUsage,omitempty"`
+	TaxonomicStatus      string `xml:"dwc:taxonomicStatus,omitempty"`
+	HigherClassification string `xml:"dwc:higherClassification,omitempty"`
+	Error                string `xml:"gn:error,omitempty"`
+}
+
+// XML returns records as XML document with Darwin Core terms.
+func XML(recs []Record) ([]byte, error) {
+	doc := xmlRecords{
+		NSDwC:     nsDwC,
+		NSDCTerms: nsDCTerms,
+		NSGN:      nsGN,
+		Records:   make([]xmlRecord, len(recs)),
+	}
+	for i, r := range recs {
+		doc.Records[i] = newXMLRecord(r)
+	}
+	res, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), res...), nil
+}
+
+func newXMLRecord(r Record) xmlRecord {
+	n := r.Name
+	res := xmlRecord{
+		Kind:         r.Kind,
+		Index:        r.Index,
+		NameID:       n.ID,
+		VerbatimName: n.Name,
+		MatchType:    n.MatchType.String(),
+		Curation:     n.Curation.String(),
+		Error:        n.Error,
+	}
+	rd := r.Result
+	if rd == nil {
+		return res
+	}
+	res.SortScore = strconv.FormatFloat(rd.SortScore, 'f', -1, 64)
+	res.EditDistance = strconv.Itoa(rd.EditDistance)
+	res.DatasetID = strconv.Itoa(rd.DataSourceID)
+	res.DatasetName = rd.DataSourceTitleShort
+	res.TaxonID = rd.RecordID
+	res.ScientificNameID = rd.GlobalID
+	res.References = rd.Outlink
+	res.ScientificName = rd.MatchedName
+	res.CanonicalName = rd.MatchedCanonicalFull
+	res.AcceptedNameUsageID = rd.CurrentRecordID
+	res.AcceptedNameUsage = rd.CurrentName
+	res.TaxonomicStatus = rd.TaxonomicStatus.String()
+	res.HigherClassification = rd.ClassificationPath
+	return res
+}
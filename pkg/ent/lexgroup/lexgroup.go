@@ -218,8 +218,8 @@ func splitByFullCanonical(gs []group) []group {
 			mp[can] = append(mp[can], gs[i].data[j])
 		}
 
-		for _, v := range mp {
-			g := toGroup(v)
+		for _, k := range sortedKeys(mp) {
+			g := toGroup(mp[k])
 			gs := splitByAuthorship(g)
 			res = append(res, gs...)
 		}
@@ -245,8 +245,8 @@ func splitByCanonical(ds []record) []group {
 		}
 	}
 	var res []group
-	for _, v := range cans {
-		res = append(res, group{data: v})
+	for _, k := range sortedKeys(cans) {
+		res = append(res, group{data: cans[k]})
 	}
 	return res
 }
@@ -277,8 +277,8 @@ func matchByOrig(g group) []group {
 			gmap[au] = []record{v}
 		}
 	}
-	for _, v := range gmap {
-		res = append(res, group{data: v})
+	for _, k := range sortedKeys(gmap) {
+		res = append(res, group{data: gmap[k]})
 	}
 	// if len(res) == 0 && len(noAu) > 0 {
 	if len(noAu) > 0 {
@@ -307,15 +307,24 @@ func matchByCombo(g group) []group {
 		return []group{gr}
 
 	}
-	for _, v := range tmp {
-		for _, vv := range noComb {
-			v = append(v, vv)
-		}
+	for _, k := range sortedKeys(tmp) {
+		v := append(tmp[k], noComb...)
 		res = append(res, group{data: v})
 	}
 	return res
 }
 
+// sortedKeys returns keys of a map in alphabetical order, so groups are
+// created in the same order for the same input.
+func sortedKeys(m map[string][]record) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
+
 // simplify authors into a string for matching
 func auToString(as *authors, withCombo bool) string {
 	var res string
@@ -412,25 +421,26 @@ func toLexicalGroups(gs []group) []LexicalGroup {
 	}
 	// then sort groups themselves. Take the best record from each group and
 	// sort by its index. The closer record to the best result (smaller its
-	// index), the better
-	slices.SortFunc(gs, func(a, b group) int {
-		return cmp.Compare(a.data[0].idx, b.data[0].idx)
+	// index), the better. Records without combination authors can belong to
+	// several groups, so ties are resolved by the rest of the records.
+	slices.SortStableFunc(gs, func(a, b group) int {
+		return slices.CompareFunc(a.data, b.data, func(x, y record) int {
+			return cmp.Compare(x.idx, y.idx)
+		})
 	})
 
 	res := make([]LexicalGroup, len(gs))
 	for i := range gs {
-		var lg LexicalGroup
-		for j := range gs[i].data {
-			if j == 0 {
-				lg = New(gs[i].data[j].rd)
-			} else {
-				code := getCode(gs[i].data[j].rd)
-				if code != "" {
-					lg.NomCodes[code] = struct{}{}
-				}
+		lg := New(gs[i].data[0].rd)
+		for _, v := range gs[i].data[1:] {
+			code := getCode(v.rd)
+			if code != "" {
+				lg.NomCodes[code] = struct{}{}
 			}
-
-			lg.Data = append(lg.Data, gs[i].data[j].rd)
+			if !slices.Contains(lg.LexicalVariants, v.rd.MatchedName) {
+				lg.LexicalVariants = append(lg.LexicalVariants, v.rd.MatchedName)
+			}
+			lg.Data = append(lg.Data, v.rd)
 		}
 		res[i] = lg
 	}
@@ -97,3 +97,30 @@ func TestBug112(t *testing.T) {
 	grps := lexgroup.NameToLexicalGroups(n)
 	assert.Equal(1, len(grps))
 }
+
+func TestLexGroupOrder(t *testing.T) {
+	assert := assert.New(t)
+	for _, f := range []string{"lexgroup2.json", "lexgroup3.json"} {
+		txt, err := os.ReadFile("../../testdata/" + f)
+		assert.Nil(err)
+		var n verifier.Name
+		err = enc.Decode(txt, &n)
+		assert.Nil(err)
+		grps := lexgroup.NameToLexicalGroups(n)
+		for range 20 {
+			grps2 := lexgroup.NameToLexicalGroups(n)
+			assert.Equal(grps, grps2, f)
+		}
+
+		var dataNum int
+		for _, g := range grps {
+			assert.Equal(g.Name, g.LexicalVariants[0])
+			assert.Equal(g.Data[0].MatchedName, g.Name)
+			for _, rd := range g.Data {
+				assert.Contains(g.LexicalVariants, rd.MatchedName)
+			}
+			dataNum += len(g.Data)
+		}
+		assert.GreaterOrEqual(dataNum, len(n.Results))
+	}
+}
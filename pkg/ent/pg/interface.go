@@ -3,17 +3,38 @@ package pg
 import (
 	"context"
 
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/gnames/gnames/pkg/ent/srch"
 	"github.com/gnames/gnames/pkg/ent/verif"
 	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnlib/ent/verifier"
 	vlib "github.com/gnames/gnlib/ent/verifier"
-	"github.com/gnames/gnquery/ent/search"
 )
 
 type PG interface {
-	// DataSourcesMap returns a map of all data-sources known to gnames.
-	// Keys are data-source IDs.
-	DataSourcesMap() map[int]*vlib.DataSource
+	// DataSources returns the registry of all data-sources known to
+	// gnames. The registry is shared, it is updated by ReloadDataSources.
+	DataSources() *dsrc.Registry
+
+	// ReloadDataSources reads metadata of data-sources from the database
+	// again and updates the registry. It returns what changed.
+	ReloadDataSources(ctx context.Context) (dsrc.Changes, error)
+
+	// Close closes connections to the database.
+	Close()
+
+	// Ping checks connectivity to the database.
+	Ping(ctx context.Context) error
+
+	// PoolStats returns the state of the database connections pool.
+	PoolStats() admin.PoolStats
+
+	// DataSourceStats computes statistics of records of a data-source.
+	// It returns dsrc.ErrNotFound if the data-source does not exist.
+	DataSourceStats(ctx context.Context, id int) (dsrc.Stats, error)
 
 	// MatchRecordsMap takes a query input, results of a matching
 	// split by type (no match, canonical match, virus match) and
@@ -29,22 +50,71 @@ type PG interface {
 	// it can also filter results by data-sources.
 	NameByID(vlib.NameStringInput) (*verif.MatchRecord, error)
 
+	// NamesByIDs finds name-strings by their IDs in one query. It returns
+	// a map of MatchRecords where keys are IDs. IDs without records are
+	// absent from the map. Results can be filtered by data-sources.
+	NamesByIDs(
+		ctx context.Context,
+		ids []string,
+		dataSources []int,
+	) (map[string]*verif.MatchRecord, error)
+
+	// RecordsByIDs finds records of a data-source by their identifiers.
+	// It returns a map where keys are identifiers. Identifiers without
+	// records are absent from the map.
+	RecordsByIDs(
+		ctx context.Context,
+		dataSourceID int,
+		idType record.IDType,
+		ids []string,
+	) (map[string][]*vlib.ResultData, error)
+
+	// CanonicalRecords finds records of all name-strings that share the
+	// canonical form with the given UUID. It returns the canonical form
+	// and at most limit records after offset. The canonical form is empty
+	// if it is unknown.
+	CanonicalRecords(
+		ctx context.Context,
+		id string,
+		kind canonical.Kind,
+		dataSources []int,
+		limit, offset int,
+	) (string, []*vlib.ResultData, error)
+
 	// NameStringByID finds a name-string in the database by its ID.
 	// It returns the name-string.
 	NameStringByID(string) (string, error)
 
+	// NameStringsByIDs finds name-strings by their IDs. It returns a map
+	// where keys are IDs. Unknown IDs are absent from the map.
+	NameStringsByIDs(ctx context.Context, ids []string) (map[string]string, error)
+
 	// SearchRecordsMap function finds records that correspond to a given
 	// advanced search input. It returns a map of MatchRecords were keys
-	// are input name-strings.
+	// are input name-strings. If input has MaxEditDist set, epithets and
+	// authors are matched fuzzily and results carry their edit distance.
 	SearchRecordsMap(
 		ctx context.Context,
-		input search.Input,
+		input srch.Input,
 		spWordIDs []int,
 		spWord string) (map[string]*verif.MatchRecord, error)
 	// GetVernaculars returns a map of vernacular names for taxons. It requires MakeVernTemp
 	// function to be called first because it uses that temporary table in the query.
 
 	// For faster lookup the results are returned as a map. In case if some error occurs,
-	// it returns an error.
-	GetVernaculars(ctx context.Context, recs []vern.Record, langs []string) (map[vern.Record][]verifier.Vernacular, error)
+	// it returns an error. If countries are given, only vernaculars used in
+	// these countries are returned.
+	GetVernaculars(
+		ctx context.Context,
+		recs []vern.Record,
+		langs []string,
+		countries []string,
+	) (map[vern.Record][]verifier.Vernacular, error)
+
+	// SearchVernaculars finds vernacular names that match the query and
+	// returns them together with scientific names of corresponding taxa.
+	SearchVernaculars(
+		ctx context.Context,
+		inp vern.SearchInput,
+	) ([]vern.SearchResult, error)
 }
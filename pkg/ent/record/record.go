@@ -0,0 +1,130 @@
+// Package record provides entities for finding names by identifiers of
+// data-source records.
+package record
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// ErrIDType is returned for unknown types of identifiers.
+var ErrIDType = errors.New("unknown type of identifier")
+
+// IDType is the kind of identifiers of data-source records.
+type IDType int
+
+// Types of identifiers.
+const (
+	// RecordID is the ID of a record in a data-source, for example
+	// a Catalogue of Life ID or an ITIS TSN.
+	RecordID IDType = iota
+
+	// LocalID is an identifier used for outlinks to the data-source.
+	LocalID
+
+	// GlobalID is a globally unique identifier of a record, for example
+	// an LSID.
+	GlobalID
+)
+
+var idTypes = []string{"record_id", "local_id", "global_id"}
+
+// NewIDType converts a string to IDType. Empty string means RecordID.
+func NewIDType(s string) (IDType, error) {
+	if s == "" {
+		return RecordID, nil
+	}
+	for i, v := range idTypes {
+		if v == s {
+			return IDType(i), nil
+		}
+	}
+	return RecordID, fmt.Errorf("record.NewIDType %q: %w", s, ErrIDType)
+}
+
+// String returns the name of the identifier type.
+func (t IDType) String() string {
+	if t < 0 || int(t) >= len(idTypes) {
+		return "unknown"
+	}
+	return idTypes[t]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t IDType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *IDType) UnmarshalText(bs []byte) error {
+	res, err := NewIDType(string(bs))
+	if err != nil {
+		return err
+	}
+	*t = res
+	return nil
+}
+
+// Input contains identifiers of records of one data-source.
+type Input struct {
+	// DataSourceID is the ID of the data-source of records.
+	DataSourceID int `json:"dataSourceId"`
+
+	// IDs are identifiers of records.
+	IDs []string `json:"ids"`
+
+	// IDType tells what kind of identifiers are given, `record_id`
+	// by default.
+	IDType IDType `json:"idType"`
+
+	// Vernaculars are languages of vernacular names to add to results.
+	// If empty, vernacular names are not returned.
+	Vernaculars []string `json:"vernaculars,omitempty"`
+}
+
+// Output contains results of the lookup in the order of input IDs.
+type Output struct {
+	Meta `json:"meta"`
+
+	// Records are results for input identifiers.
+	Records []Record `json:"records"`
+}
+
+// Meta contains options and summary of the lookup.
+type Meta struct {
+	// DataSourceID is the ID of the data-source of records.
+	DataSourceID int `json:"dataSourceId"`
+
+	// IDType is the kind of identifiers.
+	IDType IDType `json:"idType"`
+
+	// Vernaculars are languages of vernacular names.
+	Vernaculars []string `json:"vernaculars,omitempty"`
+
+	// RecordsNum is the number of input identifiers.
+	RecordsNum int `json:"recordsNum"`
+
+	// NotFoundNum is the number of identifiers without records.
+	NotFoundNum int `json:"notFoundNum"`
+}
+
+// Record is a result of the lookup of one identifier.
+type Record struct {
+	// ID is the identifier as given.
+	ID string `json:"id"`
+
+	// NotFound is true if the data-source has no records with the ID.
+	NotFound bool `json:"notFound,omitempty"`
+
+	// Results contain data of found records. Local and global IDs are not
+	// always unique, and a record can have several name-strings, so one
+	// identifier can have several results.
+	Results []*vlib.ResultData `json:"results,omitempty"`
+
+	// Vernaculars are vernacular names of the records merged from all
+	// results.
+	Vernaculars []vern.Vernacular `json:"vernaculars,omitempty"`
+}
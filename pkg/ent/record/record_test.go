@@ -0,0 +1,48 @@
+package record_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDType(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		s   string
+		typ record.IDType
+		str string
+	}{
+		{"", record.RecordID, "record_id"},
+		{"record_id", record.RecordID, "record_id"},
+		{"local_id", record.LocalID, "local_id"},
+		{"global_id", record.GlobalID, "global_id"},
+	}
+	for _, v := range tests {
+		typ, err := record.NewIDType(v.s)
+		assert.Nil(err)
+		assert.Equal(v.typ, typ)
+		assert.Equal(v.str, typ.String())
+	}
+	_, err := record.NewIDType("tsn")
+	assert.ErrorIs(err, record.ErrIDType)
+}
+
+func TestInputJSON(t *testing.T) {
+	assert := assert.New(t)
+	var inp record.Input
+	err := json.Unmarshal(
+		[]byte(`{"dataSourceId":3,"ids":["180530"],"idType":"local_id"}`), &inp,
+	)
+	assert.Nil(err)
+	assert.Equal(record.LocalID, inp.IDType)
+
+	err = json.Unmarshal([]byte(`{"idType":"tsn"}`), &inp)
+	assert.ErrorIs(err, record.ErrIDType)
+
+	bs, err := json.Marshal(record.Meta{IDType: record.GlobalID})
+	assert.Nil(err)
+	assert.Contains(string(bs), `"idType":"global_id"`)
+}
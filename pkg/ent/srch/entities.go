@@ -0,0 +1,34 @@
+package srch
+
+import (
+	"errors"
+
+	"github.com/gnames/gnquery/ent/search"
+)
+
+// MaxEditDistLimit is the highest edit distance allowed for fuzzy faceted
+// search. Higher values generate too many false positives for short words.
+const MaxEditDistLimit = 2
+
+// Input extends search.Input with settings that are not a part of the
+// query language.
+type Input struct {
+	search.Input
+
+	// MaxEditDist sets edit distance tolerance for species epithets
+	// (`sp:`, `isp:`, `asp:`) and authors (`au:`). If it is zero, only exact
+	// matches are returned.
+	MaxEditDist int `json:"maxEditDist,omitempty"`
+}
+
+// ErrNoSpecies is returned for queries without a specific or
+// infraspecific epithet. Such queries would return too many names.
+var ErrNoSpecies = errors.New("cannot run search without species epithet data")
+
+// Validate checks if the input has enough data to run the search.
+func (inp Input) Validate() error {
+	if inp.Species == "" && inp.SpeciesAny == "" && inp.SpeciesInfra == "" {
+		return ErrNoSpecies
+	}
+	return nil
+}
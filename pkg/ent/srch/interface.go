@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/gnames/gnames/pkg/ent/verif"
-	"github.com/gnames/gnquery/ent/search"
 )
 
 // Searcher is an interface that provides methods to do advanced search
@@ -14,9 +13,10 @@ type Searcher interface {
 	// information. For example, it can handle cases where the genus is
 	// abbreviated or only part of the specific epithet is known.
 	// It can also utilize year and year range information to narrow
-	// down the search.
+	// down the search. If MaxEditDist is set, epithets and authors are
+	// matched with the given edit distance tolerance.
 	AdvancedSearch(
 		ctx context.Context,
-		inp search.Input,
+		inp Input,
 	) (map[string]*verif.MatchRecord, error)
 }
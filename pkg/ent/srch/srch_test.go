@@ -0,0 +1,27 @@
+package srch_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/srch"
+	"github.com/gnames/gnquery/ent/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		msg string
+		inp search.Input
+		err error
+	}{
+		{"genus", search.Input{Genus: "Bubo"}, srch.ErrNoSpecies},
+		{"species", search.Input{Genus: "Bubo", Species: "bubo"}, nil},
+		{"any", search.Input{SpeciesAny: "bubo"}, nil},
+		{"infra", search.Input{SpeciesInfra: "bubo"}, nil},
+	}
+	for _, v := range tests {
+		err := srch.Input{Input: v.inp}.Validate()
+		assert.ErrorIs(err, v.err, v.msg)
+	}
+}
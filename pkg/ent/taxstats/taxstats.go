@@ -0,0 +1,189 @@
+// Package taxstats calculates taxonomic distribution of verified names.
+// Classification of names comes from one data-source, so names verified
+// against different taxonomic backbones can be analysed.
+package taxstats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnstats/ent/stats"
+)
+
+// Ranks are ranks of the distribution, from the highest to the lowest.
+var Ranks = []stats.Rank{
+	stats.Kingdom, stats.Phylum, stats.Class, stats.Order, stats.Family,
+}
+
+// Item is a verified name with the result that provides its
+// classification.
+type Item struct {
+	// ID is the UUID of the name-string.
+	ID string
+
+	// Name is the verified name-string.
+	Name string
+
+	// Result is the matched result from the data-source used for
+	// statistics.
+	Result *vlib.ResultData
+}
+
+// Taxons implements stats.Hierarchy interface.
+func (it Item) Taxons() []stats.Taxon {
+	var res []stats.Taxon
+	if it.Result == nil {
+		return res
+	}
+	path := strings.Split(it.Result.ClassificationPath, "|")
+	ranks := strings.Split(it.Result.ClassificationRanks, "|")
+	ids := strings.Split(it.Result.ClassificationIDs, "|")
+	if len(path) < 2 {
+		return res
+	}
+
+	res = make([]stats.Taxon, len(path))
+	for i := range path {
+		res[i] = stats.Taxon{Name: path[i]}
+		if len(ids) == len(path) {
+			res[i].ID = ids[i]
+		}
+		if len(ranks) == len(path) {
+			res[i].RankStr = ranks[i]
+			res[i].Rank = stats.NewRank(ranks[i])
+		}
+	}
+	return res
+}
+
+// Hierarchies converts items to the form required by stats.New.
+func Hierarchies(items []Item) []stats.Hierarchy {
+	res := make([]stats.Hierarchy, len(items))
+	for i := range items {
+		res[i] = items[i]
+	}
+	return res
+}
+
+// RankDist is the distribution of names between taxa of a rank.
+type RankDist struct {
+	// Rank of the taxa.
+	Rank string `json:"rank"`
+
+	// Taxa of the rank, taxa with more names go first.
+	Taxa []TaxonDist `json:"taxa"`
+}
+
+// TaxonDist is the number of names that belong to a taxon.
+type TaxonDist struct {
+	// Name of the taxon.
+	Name string `json:"name"`
+
+	// NamesNum is the number of names in the taxon.
+	NamesNum int `json:"namesNum"`
+
+	// Percentage is the share of names in the taxon from all names with
+	// classification, from 0 to 1.
+	Percentage float32 `json:"percentage"`
+}
+
+// Outlier is a name that falls outside of the main taxon.
+type Outlier struct {
+	// ID is the UUID of the name-string.
+	ID string `json:"id"`
+
+	// Name is the verified name-string.
+	Name string `json:"name"`
+
+	// MatchedName is the name that provided classification.
+	MatchedName string `json:"matchedName"`
+
+	// ClassificationPath is the classification of the matched name.
+	ClassificationPath string `json:"classificationPath"`
+}
+
+// Distribution returns the distribution of names by Ranks. Names without
+// classification are ignored. Ranks without taxa are skipped.
+func Distribution(items []Item) []RankDist {
+	var namesNum int
+	counts := make(map[stats.Rank]map[string]int)
+	for _, it := range items {
+		txs := it.Taxons()
+		if len(txs) == 0 {
+			continue
+		}
+		namesNum++
+		for _, tx := range txs {
+			if !slices.Contains(Ranks, tx.Rank) || tx.Name == "" {
+				continue
+			}
+			if counts[tx.Rank] == nil {
+				counts[tx.Rank] = make(map[string]int)
+			}
+			counts[tx.Rank][tx.Name]++
+		}
+	}
+
+	var res []RankDist
+	for _, rank := range Ranks {
+		if len(counts[rank]) == 0 {
+			continue
+		}
+		rd := RankDist{Rank: rank.String()}
+		for k, v := range counts[rank] {
+			rd.Taxa = append(rd.Taxa, TaxonDist{
+				Name:       k,
+				NamesNum:   v,
+				Percentage: float32(v) / float32(namesNum),
+			})
+		}
+		slices.SortFunc(rd.Taxa, func(a, b TaxonDist) int {
+			if c := cmp.Compare(b.NamesNum, a.NamesNum); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Name, b.Name)
+		})
+		res = append(res, rd)
+	}
+	return res
+}
+
+// OutliersLimit is the largest number of outliers in the output.
+const OutliersLimit = 100
+
+// Outliers returns names with classification that does not contain the
+// main taxon, and the number of all such names. Only the first
+// OutliersLimit names are returned. Names without classification are not
+// outliers, because their place is unknown.
+func Outliers(items []Item, mainTaxon string) ([]Outlier, int) {
+	var res []Outlier
+	var num int
+	if mainTaxon == "" {
+		return res, num
+	}
+	for _, it := range items {
+		txs := it.Taxons()
+		if len(txs) == 0 {
+			continue
+		}
+		inside := slices.ContainsFunc(txs, func(tx stats.Taxon) bool {
+			return tx.Name == mainTaxon
+		})
+		if inside {
+			continue
+		}
+		num++
+		if len(res) == OutliersLimit {
+			continue
+		}
+		res = append(res, Outlier{
+			ID:                 it.ID,
+			Name:               it.Name,
+			MatchedName:        it.Result.MatchedName,
+			ClassificationPath: it.Result.ClassificationPath,
+		})
+	}
+	return res, num
+}
@@ -0,0 +1,85 @@
+package taxstats_test
+
+import (
+	"testing"
+
+	"github.com/gnames/gnames/pkg/ent/taxstats"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnstats/ent/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func item(name, path string) taxstats.Item {
+	return taxstats.Item{
+		ID:   name,
+		Name: name,
+		Result: &vlib.ResultData{
+			MatchedName:         name,
+			ClassificationPath:  path,
+			ClassificationRanks: "kingdom|division|class|order|family|genus|species",
+		},
+	}
+}
+
+func items() []taxstats.Item {
+	return []taxstats.Item{
+		item("Bubo bubo", "Animalia|Chordata|Aves|Strigiformes|Strigidae|Bubo|Bubo bubo"),
+		item("Strix aluco", "Animalia|Chordata|Aves|Strigiformes|Strigidae|Strix|Strix aluco"),
+		item("Tyto alba", "Animalia|Chordata|Aves|Strigiformes|Tytonidae|Tyto|Tyto alba"),
+		item("Pica pica", "Animalia|Chordata|Aves|Passeriformes|Corvidae|Pica|Pica pica"),
+		{ID: "Nothing", Name: "Nothing"},
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	assert := assert.New(t)
+	res := taxstats.Distribution(items())
+	require.Len(t, res, 5)
+	var ranks []string
+	for _, v := range res {
+		ranks = append(ranks, v.Rank)
+	}
+	assert.Equal([]string{"kingdom", "phylum", "class", "order", "family"}, ranks)
+
+	assert.Equal([]taxstats.TaxonDist{{Name: "Chordata", NamesNum: 4, Percentage: 1}},
+		res[1].Taxa)
+	order := res[3].Taxa
+	require.Len(t, order, 2)
+	assert.Equal("Strigiformes", order[0].Name)
+	assert.Equal(3, order[0].NamesNum)
+	assert.Equal(float32(0.75), order[0].Percentage)
+
+	family := res[4].Taxa
+	require.Len(t, family, 3)
+	assert.Equal("Strigidae", family[0].Name)
+	assert.Equal("Corvidae", family[1].Name)
+	assert.Equal("Tytonidae", family[2].Name)
+
+	assert.Empty(taxstats.Distribution(nil))
+}
+
+func TestOutliers(t *testing.T) {
+	assert := assert.New(t)
+	its := items()
+	st := stats.New(taxstats.Hierarchies(its), 0.7)
+	assert.Equal("Strigiformes", st.MainTaxon.Name)
+
+	res, num := taxstats.Outliers(its, st.MainTaxon.Name)
+	require.Len(t, res, 1)
+	assert.Equal(1, num)
+	assert.Equal("Pica pica", res[0].Name)
+	assert.Contains(res[0].ClassificationPath, "Passeriformes")
+
+	res, num = taxstats.Outliers(its, "")
+	assert.Empty(res)
+	assert.Zero(num)
+
+	var many []taxstats.Item
+	for range taxstats.OutliersLimit + 5 {
+		many = append(many, its...)
+	}
+	res, num = taxstats.Outliers(many, st.MainTaxon.Name)
+	assert.Len(res, taxstats.OutliersLimit)
+	assert.Equal(taxstats.OutliersLimit+5, num)
+}
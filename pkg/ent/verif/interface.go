@@ -3,6 +3,10 @@ package verif
 import (
 	"context"
 
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
 	mlib "github.com/gnames/gnlib/ent/matcher"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 )
@@ -14,6 +18,39 @@ type Verifier interface {
 	// all data-sources.
 	DataSources(ids ...int) []*vlib.DataSource
 
+	// DataSource finds a data-source by its ID, UUID or short title. It
+	// returns dsrc.ErrNotFound if there is no such data-source.
+	DataSource(id string) (*vlib.DataSource, error)
+
+	// FilterDataSources returns data-sources that satisfy the filter.
+	FilterDataSources(f dsrc.Filter) []*vlib.DataSource
+
+	// ReloadDataSources updates metadata of data-sources from the database
+	// and returns what changed.
+	ReloadDataSources(ctx context.Context) (dsrc.Changes, error)
+
+	// Ping checks connectivity to the database.
+	Ping(ctx context.Context) error
+
+	// PoolStats returns the state of the database connections pool.
+	PoolStats() admin.PoolStats
+
+	// CachedResultsNum returns the number of cached results.
+	CachedResultsNum() int
+
+	// FlushDataSourceStats removes cached statistics of data-sources and
+	// returns their number.
+	FlushDataSourceStats() int
+
+	// DataSourceStats returns statistics of records of a data-source.
+	// The statistics are cached, refresh flag makes it to compute them
+	// again.
+	DataSourceStats(
+		ctx context.Context,
+		id int,
+		refresh bool,
+	) (dsrc.Stats, error)
+
 	// MatchRecords function returns unsorted records corresponding to Input
 	// matches.  Matches contain an input name-string, and strings that matched
 	// that input.
@@ -27,7 +64,42 @@ type Verifier interface {
 	// matched results or an error in case of a failure.
 	NameByID(vlib.NameStringInput) (*MatchRecord, error)
 
+	// NamesByIDs takes name-string UUIDs and returns matched results as a
+	// map where keys are UUIDs. UUIDs without results are absent from the
+	// map.
+	NamesByIDs(
+		ctx context.Context,
+		ids []string,
+		dataSources []int,
+	) (map[string]*MatchRecord, error)
+
+	// RecordsByIDs finds records of a data-source by their identifiers.
+	// It returns a map where keys are identifiers. Identifiers without
+	// records are absent from the map.
+	RecordsByIDs(
+		ctx context.Context,
+		dataSourceID int,
+		idType record.IDType,
+		ids []string,
+	) (map[string][]*vlib.ResultData, error)
+
+	// CanonicalRecords finds records of all name-strings that share the
+	// canonical form with the given UUID. It returns the canonical form
+	// and at most limit records after offset. The canonical form is empty
+	// if it is unknown.
+	CanonicalRecords(
+		ctx context.Context,
+		id string,
+		kind canonical.Kind,
+		dataSources []int,
+		limit, offset int,
+	) (string, []*vlib.ResultData, error)
+
 	// NameStringByID takes UUID as an argument and returns back a name-string
 	// that corresponds to that UUID.
 	NameStringByID(string) (string, error)
+
+	// NameStringsByIDs takes UUIDs and returns a map of corresponding
+	// name-strings. UUIDs without name-strings are absent from the map.
+	NameStringsByIDs(ctx context.Context, ids []string) (map[string]string, error)
 }
@@ -0,0 +1,49 @@
+package verif
+
+import (
+	"slices"
+
+	"github.com/gnames/gnames/pkg/ent/lexgroup"
+)
+
+// LexicalGroup contains matched name-strings that seem to belong to the
+// same scientific name. The name-strings differ by spelling, authorship
+// or ranks of infraspecies.
+type LexicalGroup struct {
+	// ID is the UUID of the name-string that represents the group.
+	ID string `json:"id"`
+
+	// Name is the name-string that represents the group.
+	Name string `json:"name"`
+
+	// Score is the sort score of the best match in the group.
+	Score float64 `json:"score"`
+
+	// NomCodes are nomenclatural codes detected for the group.
+	NomCodes []string `json:"nomCodes,omitempty"`
+
+	// LexicalVariants are all spellings of the group.
+	LexicalVariants []string `json:"lexicalVariants"`
+}
+
+// NewLexicalGroups converts lexical groups to their output form. The order
+// of groups is kept, nomenclatural codes are sorted alphabetically.
+func NewLexicalGroups(lgs []lexgroup.LexicalGroup) []LexicalGroup {
+	if len(lgs) == 0 {
+		return nil
+	}
+	res := make([]LexicalGroup, len(lgs))
+	for i, lg := range lgs {
+		res[i] = LexicalGroup{
+			ID:              lg.ID,
+			Name:            lg.Name,
+			Score:           lg.Score,
+			LexicalVariants: lg.LexicalVariants,
+		}
+		for k := range lg.NomCodes {
+			res[i].NomCodes = append(res[i].NomCodes, k)
+		}
+		slices.Sort(res[i].NomCodes)
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+package verif
+
+import vlib "github.com/gnames/gnlib/ent/verifier"
+
+// NameStringsInput contains parameters of a batch lookup of name-strings.
+type NameStringsInput struct {
+	// IDs are UUIDs or exact spellings of name-strings.
+	IDs []string `json:"ids"`
+
+	// DataSources limit results to these data-sources. If empty, all
+	// data-sources are used.
+	DataSources []int `json:"dataSources,omitempty"`
+
+	// WithAllMatches controls whether only the best match or all matches
+	// are returned.
+	WithAllMatches bool `json:"withAllMatches,omitempty"`
+
+	// WithLexicalGroups adds all matched results of a name-string grouped
+	// into lexical groups.
+	WithLexicalGroups bool `json:"withLexicalGroups,omitempty"`
+}
+
+// NameStringsOutput contains results of a batch lookup of name-strings.
+type NameStringsOutput struct {
+	// NameStringsMeta contains the options of the lookup.
+	NameStringsMeta `json:"meta"`
+
+	// Names contain results in the order of input IDs.
+	Names []NameString `json:"names"`
+}
+
+// NameStringsMeta contains the options of a batch lookup.
+type NameStringsMeta struct {
+	// DataSources that limit results.
+	DataSources []int `json:"dataSources,omitempty"`
+
+	// WithAllMatches is true if all matches are returned.
+	WithAllMatches bool `json:"withAllMatches,omitempty"`
+
+	// WithLexicalGroups is true if names contain lexical groups.
+	WithLexicalGroups bool `json:"withLexicalGroups,omitempty"`
+
+	// NamesNum is the number of input IDs.
+	NamesNum int `json:"namesNum"`
+
+	// NotFoundNum is the number of IDs without results.
+	NotFoundNum int `json:"notFoundNum"`
+}
+
+// NameString is a result of a lookup of one input ID.
+type NameString struct {
+	// Input is the UUID or the spelling of a name-string as given.
+	Input string `json:"input"`
+
+	// ID is the UUID of the name-string.
+	ID string `json:"id"`
+
+	// NotFound is true if the name-string has no records in the data-sources.
+	NotFound bool `json:"notFound,omitempty"`
+
+	// Name contains the found name data.
+	Name *vlib.Name `json:"name,omitempty"`
+
+	// LexicalGroups contain all matched results grouped into lexical groups.
+	LexicalGroups []LexicalGroup `json:"lexicalGroups,omitempty"`
+}
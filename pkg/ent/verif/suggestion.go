@@ -0,0 +1,83 @@
+package verif
+
+import (
+	"cmp"
+	"slices"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Suggestion is a low-confidence candidate for a name that did not match
+// anything. Suggestions are found with relaxed matching rules in all
+// data-sources.
+type Suggestion struct {
+	// ID is the UUID of the suggested name-string.
+	ID string `json:"id"`
+
+	// Name is the suggested name-string.
+	Name string `json:"name"`
+
+	// EditDistance is the Levenshtein edit distance between canonical
+	// forms of the input and the suggested name.
+	EditDistance int `json:"editDistance"`
+
+	// MatchType is the kind of the relaxed match.
+	MatchType vlib.MatchTypeValue `json:"matchType"`
+
+	// DataSourceID is the ID of a data-source of the best record of the
+	// suggested name.
+	DataSourceID int `json:"dataSourceId"`
+
+	// DataSourceTitleShort is the short title of the data-source.
+	DataSourceTitleShort string `json:"dataSourceTitleShort"`
+
+	// RecordID is the ID of the best record of the suggested name.
+	RecordID string `json:"recordId"`
+
+	// Verified is always false, a suggestion is a low-confidence candidate
+	// for the input, not its verification.
+	Verified bool `json:"verified"`
+
+	// FromCuratedSource is true if a curated or auto-curated data-source
+	// contains the suggested name-string.
+	FromCuratedSource bool `json:"fromCuratedSource"`
+}
+
+// NewSuggestions creates up to num suggestions out of sorted results of
+// relaxed matching. Every suggested name-string appears only once with
+// its best record. Partial matches are skipped, they usually suggest only
+// a genus of a name. Suggestions with smaller edit distance go first.
+func NewSuggestions(rds []*vlib.ResultData, num int) []Suggestion {
+	var res []Suggestion
+	seen := make(map[string]int)
+	for _, rd := range rds {
+		switch rd.MatchType {
+		case vlib.NoMatch, vlib.PartialExact, vlib.PartialFuzzy,
+			vlib.PartialFuzzyRelaxed:
+			continue
+		}
+		curated := rd.Curation != vlib.NotCurated
+		if i, ok := seen[rd.MatchedNameID]; ok {
+			res[i].FromCuratedSource = res[i].FromCuratedSource || curated
+			continue
+		}
+		seen[rd.MatchedNameID] = len(res)
+		res = append(res, Suggestion{
+			ID:                   rd.MatchedNameID,
+			Name:                 rd.MatchedName,
+			EditDistance:         rd.EditDistance,
+			MatchType:            rd.MatchType,
+			DataSourceID:         rd.DataSourceID,
+			DataSourceTitleShort: rd.DataSourceTitleShort,
+			RecordID:             rd.RecordID,
+			FromCuratedSource:    curated,
+		})
+	}
+	slices.SortStableFunc(res, func(a, b Suggestion) int {
+		return cmp.Compare(a.EditDistance, b.EditDistance)
+	})
+	if len(res) > num {
+		res = res[:num]
+	}
+	return res
+}
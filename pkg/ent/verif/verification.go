@@ -0,0 +1,172 @@
+package verif
+
+import (
+	"errors"
+
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/taxstats"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// Input extends verifier.Input with options that are specific to gnames.
+type Input struct {
+	vlib.Input
+
+	// DataSources limits verification to given data-sources. It shadows
+	// verifier.Input.DataSources and allows to refer to data-sources by
+	// their UUIDs and short titles as well as by their IDs.
+	DataSources dsrc.Identifiers `json:"dataSources,omitempty"`
+
+	// VernacularCountries limits vernacular names to the ones used in given
+	// countries. Countries are represented by ISO 3166-1 alpha-2 codes, for
+	// example `US`, `CA`. If empty, countries are ignored.
+	VernacularCountries []string `json:"vernacularCountries,omitempty"`
+
+	// WithLexicalGroups adds all matched results of a name grouped into
+	// lexical groups.
+	WithLexicalGroups bool `json:"withLexicalGroups,omitempty"`
+
+	// WithConflicts adds a report about disagreements of curated
+	// data-sources on taxonomic status, currently accepted name and
+	// classification of a name.
+	WithConflicts bool `json:"withConflicts,omitempty"`
+
+	// WithConsensus adds an aggregated opinion of curated data-sources
+	// about currently accepted name, taxonomic status and higher taxa of
+	// a name.
+	WithConsensus bool `json:"withConsensus,omitempty"`
+
+	// StatsDataSource is the ID of a data-source that provides
+	// classification for statistics. If it is given, the best result of
+	// the data-source is used for every name. If it is not among
+	// DataSources, names are matched against it separately and its records
+	// do not appear in results. If it is not given, names which best
+	// results come from the Catalogue of Life are used.
+	StatsDataSource int `json:"statsDataSource,omitempty"`
+
+	// WithContextRanking finds the main taxon of all names, and prefers
+	// results from the main taxon over their homonyms. Classification of
+	// StatsDataSource is used to find the main taxon.
+	WithContextRanking bool `json:"withContextRanking,omitempty"`
+
+	// WithSuggestions adds suggestions to names without matches. The
+	// suggestions are found with relaxed fuzzy matching in all
+	// data-sources and do not change match types of names.
+	WithSuggestions bool `json:"withSuggestions,omitempty"`
+
+	// SuggestionsNum is the maximum number of suggestions for a name,
+	// 5 by default, 20 at most.
+	SuggestionsNum int `json:"suggestionsNum,omitempty"`
+
+	// Sort changes the order of names in the output. By default names
+	// keep the order of the input. SortConsensus orders names by support
+	// of their consensus and adds the consensus to names.
+	Sort string `json:"sort,omitempty"`
+}
+
+// SortConsensus orders names from the highest to the lowest support of
+// their consensus. Names without consensus go last.
+const SortConsensus = "consensus"
+
+// ErrSort is returned for unknown sort orders.
+var ErrSort = errors.New("unknown sort order")
+
+// ErrStatsDataSource is returned if the data-source for statistics is
+// unknown.
+var ErrStatsDataSource = errors.New("unknown data-source for statistics")
+
+// Output extends verifier.Output with data that are specific to gnames.
+type Output struct {
+	Meta `json:"metadata"`
+
+	// Names contain results of verification.
+	Names []Name `json:"names"`
+}
+
+// Meta extends verifier.Meta with options that are specific to gnames.
+type Meta struct {
+	vlib.Meta
+
+	// VernacularCountries are countries that limit vernacular names.
+	VernacularCountries []string `json:"vernacularCountries,omitempty"`
+
+	// VernacularRecordsNum is the number of data-source records that were
+	// checked for vernacular names.
+	VernacularRecordsNum int `json:"vernacularRecordsNum,omitempty"`
+
+	// VernacularChunksNum is the number of chunks the records were split
+	// into for finding vernacular names. Names are never truncated, large
+	// inputs are processed in several chunks instead.
+	VernacularChunksNum int `json:"vernacularChunksNum,omitempty"`
+
+	// WithLexicalGroups is true if names contain lexical groups.
+	WithLexicalGroups bool `json:"withLexicalGroups,omitempty"`
+
+	// WithConflicts is true if names contain reports about disagreements
+	// of data-sources.
+	WithConflicts bool `json:"withConflicts,omitempty"`
+
+	// WithConsensus is true if names contain consensus of data-sources.
+	WithConsensus bool `json:"withConsensus,omitempty"`
+
+	// StatsDataSource is the ID of a data-source that provided
+	// classification for statistics.
+	StatsDataSource int `json:"statsDataSource,omitempty"`
+
+	// Ranks contain the distribution of names between taxa from kingdom
+	// to family.
+	Ranks []taxstats.RankDist `json:"ranks,omitempty"`
+
+	// Outliers are names with classification outside of the main taxon.
+	// The list is limited to taxstats.OutliersLimit names.
+	Outliers []taxstats.Outlier `json:"outliers,omitempty"`
+
+	// OutliersNum is the number of all outliers.
+	OutliersNum int `json:"outliersNum,omitempty"`
+
+	// WithContextRanking is true if results were ranked by the context.
+	WithContextRanking bool `json:"withContextRanking,omitempty"`
+
+	// ContextTaxon is the main taxon used for ranking of results. It is
+	// empty if names do not have a main taxon.
+	ContextTaxon string `json:"contextTaxon,omitempty"`
+
+	// WithSuggestions is true if names without matches contain
+	// suggestions.
+	WithSuggestions bool `json:"withSuggestions,omitempty"`
+
+	// Sort is the order of names, it is empty for the order of the input.
+	Sort string `json:"sort,omitempty"`
+}
+
+// Name is a result of verification of one name-string.
+type Name struct {
+	vlib.Name
+
+	// Vernaculars are vernacular names of the matched taxon merged from all
+	// data-sources that agree with the best match on the currently accepted
+	// name.
+	Vernaculars []vern.Vernacular `json:"vernaculars,omitempty"`
+
+	// LexicalGroups contain all matched results grouped into lexical groups.
+	// The groups are created only if WithLexicalGroups option is given.
+	LexicalGroups []LexicalGroup `json:"lexicalGroups,omitempty"`
+
+	// Conflicts report disagreements of curated data-sources about the
+	// name. It is created only if WithConflicts option is given.
+	Conflicts *conflict.Report `json:"conflicts,omitempty"`
+
+	// Consensus is an aggregated opinion of curated data-sources about
+	// the name. It is created only if WithConsensus option is given.
+	Consensus *conflict.Consensus `json:"consensus,omitempty"`
+
+	// RankedByContext is true if the best result of the name changed
+	// because of WithContextRanking option.
+	RankedByContext bool `json:"rankedByContext,omitempty"`
+
+	// Suggestions are low-confidence candidates for a name without matches.
+	// They are created only if WithSuggestions option is given.
+	Suggestions []Suggestion `json:"suggestions,omitempty"`
+}
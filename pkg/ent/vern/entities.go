@@ -1,5 +1,7 @@
 package vern
 
+import vlib "github.com/gnames/gnlib/ent/verifier"
+
 // Record contains data required for finding vernacular names for a
 // particular taxon.
 type Record struct {
@@ -11,3 +13,125 @@ type Record struct {
 	// CurrentRecordID contains ID of the current taxon record.
 	CurrentRecordID string
 }
+
+// Stats describes how vernacular names were found for verification
+// results.
+type Stats struct {
+	// RecordsNum is the number of data-source records that were checked for
+	// vernacular names.
+	RecordsNum int
+
+	// ChunksNum is the number of chunks the records were split into. Chunks
+	// are processed concurrently.
+	ChunksNum int
+}
+
+// SearchInput contains parameters for finding scientific names by their
+// vernacular names.
+type SearchInput struct {
+	// Query is a vernacular name, for example `snowy owl` or `Schneeeule`.
+	// The search ignores case, diacritics, hyphens and extra spaces.
+	Query string `json:"query"`
+
+	// Languages limits results to vernacular names in the given languages.
+	// Languages are represented by ISO 639-3 codes, for example `eng`, `deu`.
+	Languages []string `json:"languages,omitempty"`
+
+	// WithPrefix makes it possible to find vernacular names that start with
+	// the Query.
+	WithPrefix bool `json:"withPrefix,omitempty"`
+
+	// DataSources limits results to the given data-sources. It is set from
+	// access restrictions of a client, so the limit of results applies
+	// only to visible records.
+	DataSources []int `json:"-"`
+}
+
+// SearchOutput contains results of a search by vernacular names.
+type SearchOutput struct {
+	SearchMeta `json:"metadata"`
+
+	// Names contain vernacular names that matched the query together with
+	// the scientific names of corresponding taxa.
+	Names []SearchResult `json:"names"`
+}
+
+// SearchMeta provides metadata of the search.
+type SearchMeta struct {
+	// Input is the input of the search.
+	Input SearchInput `json:"input"`
+
+	// NamesNumber is the number of returned results.
+	NamesNumber int `json:"namesNumber"`
+
+	// Error contains an error message, if the search failed.
+	Error string `json:"error,omitempty"`
+}
+
+// SearchResult is a vernacular name that matched a query, together with
+// the scientific name of the taxon it belongs to.
+type SearchResult struct {
+	// Vernacular contains the vernacular name and its language.
+	Vernacular vlib.Vernacular `json:"vernacular"`
+
+	// IsPrefixMatch is true if the query matched only the beginning of the
+	// vernacular name.
+	IsPrefixMatch bool `json:"isPrefixMatch,omitempty"`
+
+	// DataSourceID is the ID of the data-source of the record.
+	DataSourceID int `json:"dataSourceId"`
+
+	// DataSourceTitleShort is a short title of the data-source.
+	DataSourceTitleShort string `json:"dataSourceTitleShort"`
+
+	// Curation is the curation level of the data-source.
+	Curation vlib.CurationLevel `json:"curation"`
+
+	// RecordID is the ID of the taxon record in the data-source.
+	RecordID string `json:"recordId"`
+
+	// Outlink is a URL to the record at the data-source website.
+	Outlink string `json:"outlink,omitempty"`
+
+	// NameID is the UUID of the scientific name-string of the taxon.
+	NameID string `json:"nameId"`
+
+	// Name is the scientific name-string of the taxon.
+	Name string `json:"name"`
+
+	// ClassificationPath is a pipe-delimited classification of the taxon.
+	ClassificationPath string `json:"classificationPath,omitempty"`
+
+	// ClassificationRanks are pipe-delimited ranks of the classification.
+	ClassificationRanks string `json:"classificationRanks,omitempty"`
+}
+
+// Vernacular is a vernacular name of a verified name-string. It merges
+// the same vernacular name provided by different data-sources.
+type Vernacular struct {
+	// Name is the vernacular name as it is given by the most curated
+	// data-source.
+	Name string `json:"name"`
+
+	// Language of the name.
+	Language string `json:"language,omitempty"`
+
+	// LanguageCode is the ISO 639-3 code of the language.
+	LanguageCode string `json:"languageCode,omitempty"`
+
+	// Localities are geographic places where the name is used.
+	Localities []string `json:"localities,omitempty"`
+
+	// Countries are codes of countries where the name is used.
+	Countries []string `json:"countries,omitempty"`
+
+	// DataSourcesNum is the number of data-sources that agree on the name.
+	DataSourcesNum int `json:"dataSourcesNum"`
+
+	// DataSourcesIDs are IDs of data-sources that provide the name.
+	DataSourcesIDs []int `json:"dataSourcesIds"`
+
+	// Preferred is true for the best vernacular name of a language. The
+	// choice depends on curation of data-sources and on their agreement.
+	Preferred bool `json:"preferred,omitempty"`
+}
@@ -1,15 +1,29 @@
 package vern
 
 import (
+	"context"
+
 	vlib "github.com/gnames/gnlib/ent/verifier"
 )
 
 // Vernaculars provides functions required to add vernacular
-// names to the results of verification. The results are
-// returned back in the same sequence they were received.
+// names to the results of verification.
 type Vernaculars interface {
+	// AddVernacularNames finds vernacular names for results of verification
+	// and adds them to the results in place. Vernaculars are limited to given
+	// languages, and, if countries are not empty, to given countries. Any
+	// number of names is supported, the records are processed in chunks.
 	AddVernacularNames(
+		ctx context.Context,
 		vernLangs []string,
+		countries []string,
 		names []vlib.Name,
-	) ([]vlib.Name, error)
+	) (Stats, error)
+
+	// SearchVernaculars finds scientific names of taxa by their vernacular
+	// names.
+	SearchVernaculars(
+		ctx context.Context,
+		inp SearchInput,
+	) ([]SearchResult, error)
 }
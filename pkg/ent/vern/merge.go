@@ -0,0 +1,124 @@
+package vern
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+// CurationRank ranks data-sources according to the curation of their
+// data. The Catalogue of Life gets the highest rank.
+func CurationRank(dataSourceID int, c vlib.CurationLevel) int {
+	if dataSourceID == 1 {
+		return int(vlib.Curated) + 1
+	}
+	return int(c)
+}
+
+// vernData accumulates information about one vernacular name that comes
+// from several data-sources.
+type vernData struct {
+	Vernacular
+	// weight is the sum of curation weights of data-sources that provide
+	// the name.
+	weight int
+	// rank is the curation rank of the data-source that provided the
+	// spelling of the name.
+	rank int
+	dss  map[int]struct{}
+}
+
+// Merge collects vernacular names from results of verification and
+// removes duplicates that come from different data-sources. Names that
+// differ only by case, diacritics or hyphens are considered the same.
+// For every language one of the names is marked as preferred, taking into
+// account curation of data-sources that provide the name and the number
+// of data-sources that agree on it.
+func Merge(rds []*vlib.ResultData) []Vernacular {
+	var keys []string
+	vm := make(map[string]*vernData)
+	for _, rd := range rds {
+		rank := CurationRank(rd.DataSourceID, rd.Curation)
+		for _, v := range rd.Vernaculars {
+			key := v.LanguageCode + "|" + Normalize(v.Name)
+			vd, ok := vm[key]
+			if !ok {
+				vd = &vernData{
+					Vernacular: Vernacular{
+						Name:         v.Name,
+						LanguageCode: v.LanguageCode,
+					},
+					rank: rank,
+					dss:  make(map[int]struct{}),
+				}
+				vm[key] = vd
+				keys = append(keys, key)
+			}
+			vd.add(rd.DataSourceID, rank, v)
+		}
+	}
+
+	vds := make([]*vernData, len(keys))
+	for i, k := range keys {
+		vds[i] = vm[k]
+	}
+	slices.SortStableFunc(vds, compareVern)
+
+	res := make([]Vernacular, len(vds))
+	var lang string
+	for i, vd := range vds {
+		v := vd.Vernacular
+		slices.Sort(v.DataSourcesIDs)
+		if v.LanguageCode != "" && (i == 0 || v.LanguageCode != lang) {
+			v.Preferred = true
+		}
+		lang = v.LanguageCode
+		res[i] = v
+	}
+	return res
+}
+
+func (vd *vernData) add(dsID, rank int, v vlib.Vernacular) {
+	if rank > vd.rank {
+		vd.Name = v.Name
+		vd.rank = rank
+	}
+	if vd.Language == "" {
+		vd.Language = v.Language
+	}
+	if v.Locality != "" && !slices.Contains(vd.Localities, v.Locality) {
+		vd.Localities = append(vd.Localities, v.Locality)
+	}
+	if v.Country != "" && !slices.Contains(vd.Countries, v.Country) {
+		vd.Countries = append(vd.Countries, v.Country)
+	}
+	if _, ok := vd.dss[dsID]; ok {
+		return
+	}
+	vd.dss[dsID] = struct{}{}
+	vd.DataSourcesIDs = append(vd.DataSourcesIDs, dsID)
+	vd.DataSourcesNum++
+	vd.weight += rank + 1
+}
+
+// compareVern groups names by language, and puts the most trusted name
+// first in each group.
+func compareVern(a, b *vernData) int {
+	if c := cmp.Compare(a.LanguageCode, b.LanguageCode); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.weight, a.weight); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.DataSourcesNum, a.DataSourcesNum); c != 0 {
+		return c
+	}
+	wordsA := strings.Count(a.Name, " ")
+	wordsB := strings.Count(b.Name, " ")
+	if c := cmp.Compare(wordsB, wordsA); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
@@ -0,0 +1,56 @@
+package vern
+
+import (
+	"slices"
+	"strings"
+)
+
+// diacritics maps letters with diacritics and hyphens to their plain
+// counterparts. The same map is used by the database to normalize
+// vernacular names during search.
+var diacritics = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a',
+	'ă': 'a', 'ą': 'a', 'ç': 'c', 'ć': 'c', 'č': 'c', 'ď': 'd', 'đ': 'd',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e', 'ė': 'e', 'ę': 'e',
+	'ě': 'e', 'ğ': 'g', 'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ī': 'i',
+	'į': 'i', 'ı': 'i', 'ł': 'l', 'ñ': 'n', 'ń': 'n', 'ň': 'n', 'ò': 'o',
+	'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o', 'ő': 'o',
+	'ř': 'r', 'ś': 's', 'š': 's', 'ş': 's', 'ș': 's', 'ť': 't', 'ţ': 't',
+	'ț': 't', 'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u', 'ů': 'u',
+	'ű': 'u', 'ų': 'u', 'ý': 'y', 'ÿ': 'y', 'ź': 'z', 'ż': 'z', 'ž': 'z',
+	'-': ' ',
+}
+
+// Normalize prepares a vernacular name for case- and accent-insensitive
+// comparison. It lowercases the name, removes diacritics, converts hyphens
+// to spaces and collapses whitespace.
+func Normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		if v, ok := diacritics[r]; ok {
+			return v
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// TranslateChars returns two strings of the same length in runes that can
+// be used as arguments of `translate` SQL function to remove diacritics
+// the same way as Normalize does. Characters are sorted, so the result is
+// always the same and can be used in the `gn_vern_norm` database function
+// (see migrations/vernacular_norm.sql).
+func TranslateChars() (string, string) {
+	keys := make([]rune, 0, len(diacritics))
+	for k := range diacritics {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var from, to strings.Builder
+	for _, k := range keys {
+		from.WriteRune(k)
+		to.WriteRune(diacritics[k])
+	}
+	return from.String(), to.String()
+}
@@ -0,0 +1,103 @@
+package vern_test
+
+import (
+	"os"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		msg, inp, out string
+	}{
+		{"empty", "", ""},
+		{"lower", "Snowy Owl", "snowy owl"},
+		{"spaces", "  snowy   owl ", "snowy owl"},
+		{"hyphen", "Schnee-Eule", "schnee eule"},
+		{"umlaut", "Schneeeule Über", "schneeeule uber"},
+		{"accents", "Búho nival", "buho nival"},
+		{"non-latin", "Белая сова", "белая сова"},
+	}
+	for _, v := range tests {
+		assert.Equal(v.out, vern.Normalize(v.inp), v.msg)
+	}
+}
+
+func TestTranslateChars(t *testing.T) {
+	assert := assert.New(t)
+	from, to := vern.TranslateChars()
+	assert.Equal(utf8.RuneCountInString(from), utf8.RuneCountInString(to))
+	assert.Contains(from, "é")
+
+	// translate arguments of the database function must be the same.
+	sql, err := os.ReadFile("../../../migrations/vernacular_norm.sql")
+	assert.Nil(err)
+	assert.Contains(string(sql), "'"+from+"'")
+	assert.Contains(string(sql), "'"+to+"'")
+}
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+	rds := []*vlib.ResultData{
+		{
+			DataSourceID: 11,
+			Curation:     vlib.AutoCurated,
+			Vernaculars: []vlib.Vernacular{
+				{Name: "snowy owl", LanguageCode: "eng", Country: "US"},
+				{Name: "Polar Owl", LanguageCode: "eng"},
+				{Name: "Harfang des neiges", LanguageCode: "fra"},
+			},
+		},
+		{
+			DataSourceID: 1,
+			Curation:     vlib.Curated,
+			Vernaculars: []vlib.Vernacular{
+				{Name: "Snowy Owl", Language: "English", LanguageCode: "eng",
+					Locality: "Alaska", Country: "US"},
+				{Name: "Snowy-Owl", LanguageCode: "eng", Country: "CA"},
+			},
+		},
+		{
+			DataSourceID: 12,
+			Curation:     vlib.Curated,
+			Vernaculars: []vlib.Vernacular{
+				{Name: "Polar Owl", LanguageCode: "eng"},
+			},
+		},
+	}
+	res := vern.Merge(rds)
+	assert.Len(res, 3)
+
+	eng := res[0]
+	assert.Equal("Snowy Owl", eng.Name)
+	assert.Equal("English", eng.Language)
+	assert.True(eng.Preferred)
+	assert.Equal(2, eng.DataSourcesNum)
+	assert.Equal([]int{1, 11}, eng.DataSourcesIDs)
+	assert.Equal([]string{"Alaska"}, eng.Localities)
+	assert.Equal([]string{"US", "CA"}, eng.Countries)
+
+	assert.Equal("Polar Owl", res[1].Name)
+	assert.False(res[1].Preferred)
+	assert.Equal(2, res[1].DataSourcesNum)
+
+	assert.Equal("fra", res[2].LanguageCode)
+	assert.True(res[2].Preferred)
+
+	assert.Empty(vern.Merge(nil))
+}
+
+func TestCurationRank(t *testing.T) {
+	assert := assert.New(t)
+	col := vern.CurationRank(1, vlib.Curated)
+	assert.Greater(col, vern.CurationRank(12, vlib.Curated))
+	assert.Greater(
+		vern.CurationRank(12, vlib.Curated),
+		vern.CurationRank(11, vlib.AutoCurated),
+	)
+}
@@ -1,16 +1,25 @@
 package gnames
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/gnames/gnames/pkg/ent/recon"
+	"github.com/gnames/gnames/pkg/ent/verif"
 	"github.com/gnames/gnfmt"
 	"github.com/gnames/gnlib/ent/reconciler"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 )
 
-func (g gnames) ExtendReconcile(q reconciler.ExtendQuery) (reconciler.ExtendOutput, error) {
+// ExtendReconcile adds properties of the best matches to reconciled
+// name-strings. The name-strings are verified again with all matches and
+// species groups, so records of other spellings of the same name are
+// included. All name-strings are found and verified in one batch.
+func (g gnames) ExtendReconcile(
+	ctx context.Context,
+	q reconciler.ExtendQuery,
+) (reconciler.ExtendOutput, error) {
 	enc := gnfmt.GNjson{Pretty: false}
 	rows := make(map[string]map[string][]reconciler.PropertyValue)
 	var props []reconciler.Property
@@ -25,17 +34,16 @@ func (g gnames) ExtendReconcile(q reconciler.ExtendQuery) (reconciler.ExtendOutp
 		Meta: props,
 		Rows: rows,
 	}
+	names, err := g.verifyIDs(ctx, q.IDs)
+	if err != nil {
+		return res, fmt.Errorf("gnames.ExtendReconcile: %w", err)
+	}
+
 	propRes := make(map[string]string)
-	for _, v := range q.IDs {
-		ns, err := g.NameByID(vlib.NameStringInput{
-			ID:             v,
-			WithAllMatches: true,
-		}, true)
-		if err != nil {
-			return res, fmt.Errorf("gnames.ExtendReconcile: %w", err)
-		}
+	for _, id := range q.IDs {
+		ns, ok := names[id]
 		// should not happen during reconciliation
-		if len(ns.Results) == 0 {
+		if !ok || len(ns.Results) == 0 {
 			continue
 		}
 
@@ -58,12 +66,52 @@ func (g gnames) ExtendReconcile(q reconciler.ExtendQuery) (reconciler.ExtendOutp
 		propRes[recon.OutlinkURL.Property().ID] = bestResult.Outlink
 		propRes[recon.AllDataSources.Property().ID] = dataSourcesDetJSON
 		row := extensionRow(q.Properties, propRes)
-		res.Rows[v] = row
+		res.Rows[id] = row
 		clear(propRes)
 	}
 	return res, nil
 }
 
+// verifyIDs verifies name-strings of given UUIDs the same way as
+// NameByID with the full match does. It returns results by UUIDs, unknown
+// UUIDs are absent from the result.
+func (g gnames) verifyIDs(
+	ctx context.Context,
+	ids []string,
+) (map[string]vlib.Name, error) {
+	nameStrings, err := g.vf.NameStringsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("gnames.verifyIDs: %w", err)
+	}
+
+	var uuids, names []string
+	for _, id := range ids {
+		if name, ok := nameStrings[id]; ok {
+			uuids = append(uuids, id)
+			names = append(names, name)
+			// the same UUID can be given several times
+			delete(nameStrings, id)
+		}
+	}
+	res := make(map[string]vlib.Name, len(uuids))
+	if len(names) == 0 {
+		return res, nil
+	}
+
+	out, err := g.VerifyWithOptions(ctx, verif.Input{Input: vlib.Input{
+		NameStrings:      names,
+		WithAllMatches:   true,
+		WithSpeciesGroup: true,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("gnames.verifyIDs: %w", err)
+	}
+	for i := range out.Names {
+		res[uuids[i]] = out.Names[i].Name
+	}
+	return res, nil
+}
+
 type hierarchy struct {
 	Taxon string `json:"taxon"`
 	Rank  string `json:"rank"`
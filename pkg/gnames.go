@@ -1,6 +1,9 @@
 package gnames
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/gnames/gnames/internal/io/matcher"
 	"github.com/gnames/gnames/pkg/config"
 	"github.com/gnames/gnames/pkg/ent/srch"
@@ -8,6 +11,8 @@ import (
 	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnlib/ent/gnvers"
 	gnmatcher "github.com/gnames/gnmatcher/pkg"
+	"github.com/gnames/gnparser"
+	"golang.org/x/sync/singleflight"
 )
 
 // Option is a functional option for the gnames constructor.
@@ -27,8 +32,15 @@ type gnames struct {
 	vern    vern.Vernaculars
 	sr      srch.Searcher
 	matcher gnmatcher.GNmatcher
+	// canary makes concurrent readiness checks share one canary match.
+	canary *singleflight.Group
+	// gnpPool keeps parsers for requests that need canonical forms.
+	gnpPool chan gnparser.GNparser
 }
 
+// parsersNum is the number of parsers in the pool.
+const parsersNum = 5
+
 // New is a constructor that returns implmentation of GNames interface.
 // When cfg.MatcherURL is empty, an embedded gnmatcher is initialised.
 // When cfg.MatcherURL is set, an HTTP client to a remote service is used.
@@ -41,10 +53,15 @@ func New(
 	opts ...Option,
 ) (GNames, error) {
 	g := &gnames{
-		cfg:  cfg,
-		vf:   vf,
-		vern: vern,
-		sr:   sr,
+		cfg:     cfg,
+		vf:      vf,
+		vern:    vern,
+		sr:      sr,
+		canary:  &singleflight.Group{},
+		gnpPool: make(chan gnparser.GNparser, parsersNum),
+	}
+	for range parsersNum {
+		g.gnpPool <- gnparser.New(gnparser.NewConfig())
 	}
 
 	for _, opt := range opts {
@@ -66,6 +83,16 @@ func New(
 	return *g, nil
 }
 
+// Close releases resources of the embedded matcher.
+func (g gnames) Close() error {
+	if c, ok := g.matcher.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("gnames.Close: %w", err)
+		}
+	}
+	return nil
+}
+
 func (g gnames) GetVersion() gnvers.Version {
 	return gnvers.Version{
 		Version: Version,
@@ -2,16 +2,31 @@ package gnames_test
 
 import (
 	"context"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	gnames "github.com/gnames/gnames/pkg"
 	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/gnames/gnames/pkg/ent/srch"
 	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	"github.com/gnames/gnfmt"
 	mlib "github.com/gnames/gnlib/ent/matcher"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/gnames/gnlib/ent/gnvers"
+	"github.com/gnames/gnlib/ent/reconciler"
+	"github.com/gnames/gnuuid"
 	gnmcfg "github.com/gnames/gnmatcher/pkg/config"
-	"github.com/gnames/gnquery/ent/search"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,27 +45,667 @@ func TestVerifier(t *testing.T) {
 		{"Bubo bubo"},
 	}
 	for _, v := range testData {
-		_, err := g.Verify(ctx, vlib.Input{NameStrings: []string{v.name}})
+		res, err := g.Verify(ctx, vlib.Input{NameStrings: []string{v.name}})
 		assert.Nil(t, err)
+		assert.Equal(t, 1, res.NamesNumber)
+		assert.Equal(t, v.name, res.Names[0].Name)
+		assert.Equal(t, 1, res.Names[0].BestResult.DataSourceID)
 	}
 }
 
-type mockVerifier struct{}
+func TestVerifyDataSources(t *testing.T) {
+	ctx := context.Background()
+	g := newTestGnames(t)
+
+	inp := verif.Input{
+		Input:       vlib.Input{NameStrings: []string{"Bubo bubo"}},
+		DataSources: dsrc.Identifiers{"1", "12"},
+	}
+	res, err := g.VerifyWithOptions(ctx, inp)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 12}, res.DataSources)
+
+	inp.DataSources = dsrc.Identifiers{"1", "no such source"}
+	_, err = g.VerifyWithOptions(ctx, inp)
+	assert.ErrorIs(t, err, dsrc.ErrNotFound)
+
+	inp.DataSources = dsrc.Identifiers{"1", "9999"}
+	_, err = g.VerifyWithOptions(ctx, inp)
+	assert.ErrorIs(t, err, dsrc.ErrNotFound)
+}
+
+func TestVerifyStats(t *testing.T) {
+	ctx := context.Background()
+	g := newTestGnames(t)
+
+	inp := verif.Input{Input: vlib.Input{
+		NameStrings: []string{"Bubo bubo", "Nothing"},
+		WithStats:   true,
+	}}
+	res, err := g.VerifyWithOptions(ctx, inp)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res.StatsDataSource)
+	assert.Equal(t, 1, res.StatsNamesNum)
+
+	// the data-source for statistics is not among requested ones
+	inp.DataSources = dsrc.Identifiers{"1"}
+	inp.StatsDataSource = 12
+	inp.WithAllMatches = true
+	res, err = g.VerifyWithOptions(ctx, inp)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, res.DataSources)
+	assert.Equal(t, 12, res.StatsDataSource)
+	assert.Equal(t, 1, res.StatsNamesNum)
+	for _, rd := range res.Names[0].Results {
+		assert.Equal(t, 1, rd.DataSourceID)
+	}
+	assert.Len(t, res.Names[0].Results, 1)
+
+	inp.StatsDataSource = 9999
+	_, err = g.VerifyWithOptions(ctx, inp)
+	assert.ErrorIs(t, err, verif.ErrStatsDataSource)
+
+	inp.StatsDataSource = 12
+	ctx = access.NewContext(ctx, access.Access{DataSources: []int{1}})
+	_, err = g.VerifyWithOptions(ctx, inp)
+	assert.ErrorIs(t, err, verif.ErrStatsDataSource)
+}
+
+func TestVerifyAccess(t *testing.T) {
+	g := newTestGnames(t)
+
+	inp := verif.Input{Input: vlib.Input{
+		NameStrings:    []string{"Bubo bubo"},
+		WithAllMatches: true,
+	}}
+	res, err := g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(t, err)
+	assert.Len(t, res.Names[0].Results, 2)
+
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{1}})
+	res, err = g.VerifyWithOptions(ctx, inp)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, res.DataSources)
+	assert.Len(t, res.Names[0].Results, 1)
+	assert.Equal(t, 1, res.Names[0].Results[0].DataSourceID)
+
+	inp.DataSources = dsrc.Identifiers{"12"}
+	_, err = g.VerifyWithOptions(ctx, inp)
+	assert.ErrorIs(t, err, dsrc.ErrNotFound)
+}
+
+func TestSearchVernacularsAccess(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	inp := vern.SearchInput{Query: "eagle owl"}
+	res := g.SearchVernaculars(context.Background(), inp)
+	assert.Equal(2, res.NamesNumber)
+
+	// hidden data-sources are excluded by the search itself
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{12}})
+	res = g.SearchVernaculars(ctx, inp)
+	assert.Equal(1, res.NamesNumber)
+	assert.Equal(12, res.Names[0].DataSourceID)
+	assert.Empty(res.Input.DataSources)
+}
+
+func TestNameStrings(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	bubo := gnuuid.New("Bubo bubo").String()
+	inp := verif.NameStringsInput{
+		IDs:            []string{"Bubo bubo", "Nothing here", bubo},
+		WithAllMatches: true,
+	}
+	res, err := g.NameStrings(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(3, res.NamesNum)
+	assert.Equal(1, res.NotFoundNum)
+	assert.Len(res.Names, 3)
+	assert.Equal("Bubo bubo", res.Names[0].Input)
+	assert.Equal(bubo, res.Names[0].ID)
+	assert.Len(res.Names[0].Name.Results, 2)
+	assert.True(res.Names[1].NotFound)
+	assert.Nil(res.Names[1].Name)
+	assert.Equal(bubo, res.Names[2].Input)
+	assert.False(res.Names[2].NotFound)
+
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{1}})
+	res, err = g.NameStrings(ctx, inp)
+	assert.Nil(err)
+	assert.Len(res.Names[0].Name.Results, 1)
+	assert.Equal(1, res.Names[0].Name.Results[0].DataSourceID)
+
+	inp.DataSources = []int{12}
+	_, err = g.NameStrings(ctx, inp)
+	assert.ErrorIs(err, dsrc.ErrNotFound)
+}
+
+func TestReconcile(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	txt, err := os.ReadFile("testdata/lexgroup2.json")
+	assert.Nil(err)
+	var n vlib.Name
+	err = gnfmt.GNjson{}.Decode(txt, &n)
+	assert.Nil(err)
+	out := verif.Output{Names: []verif.Name{{Name: n}}}
+	ids := []string{"q0"}
+
+	qs := map[string]reconciler.Query{"q0": {Query: n.Name}}
+	res := g.Reconcile(out, qs, ids)
+	rcs := res["q0"].Result
+	assert.Len(rcs, 8)
+	tests := []struct {
+		name  string
+		score float64
+	}{
+		{"Carex scirpoidea var. convoluta Kük.", 1},
+		{"Carex scirpoidea subsp. convoluta (Kük.) Dunlop", 0.9},
+		{"Carex scirpoidea ssp. convoluta (Dimus) Primus", 0.9},
+		{"Carex scirpoidea var. convoluta", 0.9},
+		{"Carex scirpoidea subsp. convoluta", 0.9},
+		{"Carex scirpoidea convoluta", 0.9},
+		{"Carex scirpoidea convoluta Kükenth.", 0.855},
+		{"Carex scirpoidea convoluta Gardner", 0.855},
+	}
+	for i, v := range tests {
+		assert.Equal(v.name, rcs[i].Name)
+		assert.InDelta(v.score, rcs[i].Score, 0.0001, v.name)
+		assert.Equal(i == 0, rcs[i].Match, v.name)
+	}
+	assert.Equal("first_result", rcs[0].Features[0].ID)
+
+	qs["q0"] = reconciler.Query{
+		Query: n.Name,
+		Properties: []reconciler.PropertyInfo{
+			{PropertyID: "data_source_ids", PropertyValue: "1,11"},
+		},
+	}
+	res = g.Reconcile(out, qs, ids)
+	rcs = res["q0"].Result
+	assert.Len(rcs, 2)
+	assert.Equal(tests[0].name, rcs[0].Name)
+	assert.Equal(tests[1].name, rcs[1].Name)
+}
+
+func TestExtendReconcile(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	bubo := gnuuid.New("Bubo bubo").String()
+	nothing := gnuuid.New("Nothing here").String()
+	q := reconciler.ExtendQuery{
+		IDs: []string{bubo, nothing},
+		Properties: []reconciler.Property{
+			{ID: "canonical_form"}, {ID: "all_data_sources"}, {ID: "bad"},
+		},
+	}
+	res, err := g.ExtendReconcile(context.Background(), q)
+	assert.Nil(err)
+	assert.Len(res.Meta, 2)
+	assert.Len(res.Rows, 1)
+	row := res.Rows[bubo]
+	assert.Contains(row, "canonical_form")
+	assert.Contains(row, "all_data_sources")
+	assert.NotContains(row, "bad")
+}
+
+func TestExtendReconcileSpelling(t *testing.T) {
+	assert := assert.New(t)
+	// records of the name are attached to another spelling of it
+	g := newTestGnames(t, withRecords(
+		func(v mlib.Match, _ vlib.Input) []*vlib.ResultData {
+			name := "Bubo bubo (Linnaeus, 1758)"
+			return []*vlib.ResultData{{
+				DataSourceID:           1,
+				DataSourceTitleShort:   "Catalogue of Life",
+				MatchedName:            name,
+				MatchedCanonicalSimple: "Bubo bubo",
+				CurrentName:            name,
+				ClassificationPath:     "Animalia|Bubo|Bubo bubo",
+				ClassificationRanks:    "kingdom|genus|species",
+				ScoreDetails:           vlib.ScoreDetails{AuthorMatchScore: 0.5},
+			}}
+		}))
+
+	id := gnuuid.New("Bubo bubo L.").String()
+	q := reconciler.ExtendQuery{
+		IDs: []string{id},
+		Properties: []reconciler.Property{
+			{ID: "current_name"}, {ID: "all_data_sources"},
+		},
+	}
+	res, err := g.ExtendReconcile(context.Background(), q)
+	assert.Nil(err)
+	row := res.Rows[id]
+	assert.Equal("Bubo bubo (Linnaeus, 1758)", row["current_name"][0].Str)
+	assert.Contains(row["all_data_sources"][0].Str, "Catalogue of Life")
+}
+
+func TestConflicts(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	inp := conflict.Input{Name: "Bubo bubo"}
+	res, err := g.Conflicts(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal("Bubo bubo", res.Input)
+	assert.Equal("Bubo bubo", res.MatchedName)
+	assert.Equal([]int{1, 12}, res.DataSources)
+	assert.Len(res.Conflicts, 1)
+	assert.Equal(conflict.TaxonomicStatus, res.Conflicts[0].Field)
+	assert.Nil(res.Consensus)
+
+	inp.WithConsensus = true
+	res, err = g.Conflicts(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(2, res.Consensus.DataSourcesNum)
+	assert.Equal(0.5, res.Consensus.AcceptedShare)
+	inp.WithConsensus = false
+
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{1}})
+	res, err = g.Conflicts(ctx, inp)
+	assert.Nil(err)
+	assert.Equal(1, res.DataSourcesNum)
+	assert.True(res.IsEmpty())
+
+	inp.DataSources = []int{12}
+	_, err = g.Conflicts(ctx, inp)
+	assert.ErrorIs(err, dsrc.ErrNotFound)
+
+	out, err := g.VerifyWithOptions(context.Background(), verif.Input{
+		Input: vlib.Input{NameStrings: []string{"Bubo bubo"}},
+	})
+	assert.Nil(err)
+	assert.Nil(out.Names[0].Conflicts)
+	assert.Nil(out.Names[0].Consensus)
+}
+
+func TestSortByConsensus(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	inp := verif.Input{
+		Input: vlib.Input{
+			NameStrings: []string{"Nothing here", "Bubo bubo", "Strix aluco"},
+		},
+		Sort: verif.SortConsensus,
+	}
+	out, err := g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(verif.SortConsensus, out.Sort)
+	assert.True(out.WithConsensus)
+	assert.Len(out.Names, 3)
+	// names with the same support keep the order of the input.
+	assert.Equal("Bubo bubo", out.Names[0].Name.Name)
+	assert.Equal("Strix aluco", out.Names[1].Name.Name)
+	assert.NotNil(out.Names[0].Consensus)
+	assert.Nil(out.Names[2].Consensus)
+
+	inp.Sort = "support"
+	_, err = g.VerifyWithOptions(context.Background(), inp)
+	assert.ErrorIs(err, verif.ErrSort)
+}
+
+func TestContextRanking(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t, withRecords(contextRecords))
+
+	inp := verif.Input{Input: vlib.Input{
+		NameStrings: []string{"Bubo bubo", "Strix aluco", "Aus bus", "Aus bus"},
+	}}
+	res, err := g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal("Plantae", res.Names[2].BestResult.ClassificationPath[:7])
+	assert.False(res.Names[2].RankedByContext)
+	assert.Empty(res.ContextTaxon)
+
+	inp.WithContextRanking = true
+	res, err = g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.True(res.WithContextRanking)
+	assert.Equal("Strigidae", res.ContextTaxon)
+	for _, i := range []int{2, 3} {
+		aus := res.Names[i]
+		assert.True(aus.RankedByContext)
+		assert.Equal("Animalia", aus.BestResult.ClassificationPath[:8])
+	}
+	assert.False(res.Names[0].RankedByContext)
+}
+
+// contextRecords returns a plant and an animal homonyms for `Aus bus`.
+func contextRecords(v mlib.Match, _ vlib.Input) []*vlib.ResultData {
+	bird := func(name, genus string) *vlib.ResultData {
+		return &vlib.ResultData{
+			DataSourceID:           1,
+			MatchedName:            name,
+			MatchedCanonicalSimple: name,
+			ClassificationPath:     "Animalia|Aves|Strigidae|" + genus + "|" + name,
+			ClassificationRanks:    "kingdom|class|family|genus|species",
+		}
+	}
+	if v.Name != "Aus bus" {
+		return []*vlib.ResultData{bird(v.Name, strings.Fields(v.Name)[0])}
+	}
+	plant := bird(v.Name, "Aus")
+	plant.ClassificationPath = "Plantae|Magnoliopsida|Rosaceae|Aus|Aus bus"
+	return []*vlib.ResultData{plant, bird(v.Name, "Aus")}
+}
+
+func TestSuggestions(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t, withRecords(suggestRecords),
+		withMatcher(mockMatcher{fuzzyRelaxed: true}))
+
+	inp := verif.Input{Input: vlib.Input{NameStrings: []string{"Bubu bobo"}}}
+	res, err := g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(vlib.NoMatch, res.Names[0].MatchType)
+	assert.Empty(res.Names[0].Suggestions)
+
+	inp.WithSuggestions = true
+	res, err = g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.True(res.WithSuggestions)
+	name := res.Names[0]
+	assert.Equal(vlib.NoMatch, name.MatchType)
+	assert.Nil(name.BestResult)
+	assert.Len(name.Suggestions, 2)
+	assert.Equal("Bubo bobo", name.Suggestions[0].Name)
+	assert.Equal(1, name.Suggestions[0].EditDistance)
+	assert.False(name.Suggestions[0].Verified)
+	assert.False(name.Suggestions[0].FromCuratedSource)
+	assert.Equal("Bubo bubo", name.Suggestions[1].Name)
+	assert.False(name.Suggestions[1].Verified)
+	assert.True(name.Suggestions[1].FromCuratedSource)
+
+	inp.SuggestionsNum = 1
+	res, err = g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.Len(res.Names[0].Suggestions, 1)
+
+	// relaxed matching in the requested data-source finds nothing
+	inp.SuggestionsNum = 0
+	inp.WithRelaxedFuzzyMatch = true
+	inp.WithUninomialFuzzyMatch = true
+	inp.DataSources = dsrc.Identifiers{"1"}
+	res, err = g.VerifyWithOptions(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(vlib.NoMatch, res.Names[0].MatchType)
+	assert.Len(res.Names[0].Suggestions, 2)
+}
+
+// suggestRecords returns results only for fuzzy matches. All results come
+// from the data-source 12.
+func suggestRecords(v mlib.Match, input vlib.Input) []*vlib.ResultData {
+	if v.MatchType == vlib.NoMatch ||
+		(len(input.DataSources) > 0 && !slices.Contains(input.DataSources, 12)) {
+		return nil
+	}
+	fuzzy := func(name string, dist int, cur vlib.CurationLevel) *vlib.ResultData {
+		return &vlib.ResultData{
+			DataSourceID:  12,
+			MatchedNameID: gnuuid.New(name).String(),
+			MatchedName:   name,
+			EditDistance:  dist,
+			MatchType:     vlib.Fuzzy,
+			Curation:      cur,
+		}
+	}
+	genus := fuzzy("Bubo", 0, vlib.Curated)
+	genus.MatchType = vlib.PartialFuzzy
+	return []*vlib.ResultData{
+		fuzzy("Bubo bubo", 2, vlib.NotCurated),
+		fuzzy("Bubo bubo", 2, vlib.Curated),
+		fuzzy("Bubo bobo", 1, vlib.NotCurated),
+		genus,
+	}
+}
+
+func TestRecords(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	inp := record.Input{DataSourceID: 1, IDs: []string{"3FDQ", "none"}}
+	res, err := g.Records(context.Background(), inp)
+	assert.Nil(err)
+	assert.Equal(2, res.RecordsNum)
+	assert.Equal(1, res.NotFoundNum)
+	assert.Equal("3FDQ", res.Records[0].ID)
+	assert.Len(res.Records[0].Results, 1)
+	assert.Equal("Bubo bubo", res.Records[0].Results[0].MatchedName)
+	assert.True(res.Records[1].NotFound)
+
+	inp.DataSourceID = 1000
+	_, err = g.Records(context.Background(), inp)
+	assert.ErrorIs(err, dsrc.ErrNotFound)
+
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{1}})
+	inp.DataSourceID = 12
+	_, err = g.Records(ctx, inp)
+	assert.ErrorIs(err, dsrc.ErrNotFound)
+}
+
+func TestCanonical(t *testing.T) {
+	assert := assert.New(t)
+	g := newTestGnames(t)
+
+	for _, v := range []string{
+		"Bubo bubo", "Bubo bubo L.", gnuuid.New("Bubo bubo").String(),
+	} {
+		res, err := g.Canonical(context.Background(), canonical.Input{ID: v})
+		assert.Nil(err)
+		assert.Equal("Bubo bubo", res.Canonical, v)
+		assert.Equal(2, res.NameStringsNum)
+		assert.Equal(4, res.RecordsNum)
+		assert.Equal(2, res.GroupsNum)
+
+		grp := res.Groups[0]
+		assert.Equal("Bubo bubo (Linnaeus, 1758)", grp.Name)
+		assert.Equal([]string{"ICZN"}, grp.NomCodes)
+		assert.Len(grp.NameStrings, 1)
+		assert.Equal([]int{1, 12}, grp.NameStrings[0].DataSources)
+		assert.Equal(3, grp.NameStrings[0].RecordsNum)
+		assert.Equal("Bubo bubo Linnaeus, 1758", res.Groups[1].Name)
+	}
+
+	res, err := g.Canonical(context.Background(),
+		canonical.Input{ID: "Bubo bubo", Limit: 3})
+	assert.Nil(err)
+	assert.Equal(3, res.RecordsNum)
+	assert.Equal(3, res.NextOffset)
+	res, err = g.Canonical(context.Background(),
+		canonical.Input{ID: "Bubo bubo", Limit: 3, Offset: 3})
+	assert.Nil(err)
+	assert.Equal(1, res.RecordsNum)
+	assert.Zero(res.NextOffset)
+
+	res, err = g.Canonical(context.Background(),
+		canonical.Input{ID: "Pica pica"})
+	assert.ErrorIs(err, canonical.ErrNotFound)
+	assert.Empty(res.Canonical)
+	assert.Empty(res.Groups)
+
+	ctx := access.NewContext(context.Background(),
+		access.Access{DataSources: []int{12}})
+	res, err = g.Canonical(ctx, canonical.Input{ID: "Bubo bubo"})
+	assert.Nil(err)
+	assert.Equal(1, res.NameStringsNum)
+	assert.Equal(2, res.RecordsNum)
+}
+
+func TestReadiness(t *testing.T) {
+	g := newTestGnames(t)
+
+	res := g.Readiness(context.Background())
+	assert.False(t, res.Ready)
+	ready := make(map[string]bool)
+	for _, c := range res.Components {
+		ready[c.Name] = c.Ready
+		assert.GreaterOrEqual(t, c.LatencyMs, 0.0)
+	}
+	assert.Equal(t, map[string]bool{
+		"database":    true,
+		"dataSources": false,
+		"matcher":     true,
+	}, ready)
+}
+
+func TestReadinessTimeout(t *testing.T) {
+	assert := assert.New(t)
+	m := mockMatcher{calls: &atomic.Int32{}, release: make(chan struct{})}
+	g := newTestGnames(t, withMatcher(m))
+
+	// checks that time out share the same canary match
+	for range 3 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		res := g.Readiness(ctx)
+		cancel()
+		assert.False(res.Ready)
+	}
+	assert.Equal(int32(1), m.calls.Load())
+
+	close(m.release)
+	res := g.Readiness(context.Background())
+	for _, c := range res.Components {
+		if c.Name == "matcher" {
+			assert.True(c.Ready)
+		}
+	}
+}
+
+// testOption changes mocks used by newTestGnames.
+type testOption func(vf *mockVerifier, m *mockMatcher)
+
+// withRecords sets results of matches returned by the mock verifier.
+func withRecords(f func(mlib.Match, vlib.Input) []*vlib.ResultData) testOption {
+	return func(vf *mockVerifier, _ *mockMatcher) {
+		vf.records = f
+	}
+}
+
+// withMatcher replaces the default mock matcher.
+func withMatcher(m mockMatcher) testOption {
+	return func(_ *mockVerifier, mm *mockMatcher) {
+		*mm = m
+	}
+}
+
+// newTestGnames creates GNames with mock dependencies.
+func newTestGnames(t *testing.T, opts ...testOption) gnames.GNames {
+	t.Helper()
+	var vf mockVerifier
+	var m mockMatcher
+	for _, opt := range opts {
+		opt(&vf, &m)
+	}
+	g, err := gnames.New(config.New(), vf, mockVernacular{}, mockFacet{},
+		gnames.WithMatcher(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+type mockVerifier struct {
+	// records creates results of a match. Default results are used if it
+	// is nil.
+	records func(mlib.Match, vlib.Input) []*vlib.ResultData
+}
 
 func (m mockVerifier) DataSources(ids ...int) []*vlib.DataSource {
 	var res []*vlib.DataSource
 	return res
 }
 
+func (m mockVerifier) DataSource(id string) (*vlib.DataSource, error) {
+	switch id {
+	case "1":
+		return &vlib.DataSource{ID: 1}, nil
+	case "12":
+		return &vlib.DataSource{ID: 12}, nil
+	}
+	return nil, dsrc.ErrNotFound
+}
+
+func (m mockVerifier) FilterDataSources(f dsrc.Filter) []*vlib.DataSource {
+	return nil
+}
+
+func (m mockVerifier) Ping(ctx context.Context) error { return nil }
+
+func (m mockVerifier) PoolStats() admin.PoolStats {
+	return admin.PoolStats{}
+}
+
+func (m mockVerifier) CachedResultsNum() int { return 0 }
+
+func (m mockVerifier) FlushDataSourceStats() int { return 0 }
+
+func (m mockVerifier) ReloadDataSources(
+	context.Context,
+) (dsrc.Changes, error) {
+	return dsrc.Changes{}, nil
+}
+
+func (m mockVerifier) DataSourceStats(
+	ctx context.Context,
+	id int,
+	refresh bool,
+) (dsrc.Stats, error) {
+	return dsrc.Stats{DataSourceID: id}, nil
+}
+
 func (m mockVerifier) MatchRecords(
 	ctx context.Context,
 	fmatches []mlib.Match,
 	input vlib.Input,
 ) (map[string]*verif.MatchRecord, error) {
-	var res map[string]*verif.MatchRecord
+	records := m.records
+	if records == nil {
+		records = defaultRecords
+	}
+	res := make(map[string]*verif.MatchRecord)
+	for _, v := range fmatches {
+		rds := records(v, input)
+		if len(rds) == 0 {
+			continue
+		}
+		res[v.ID] = &verif.MatchRecord{ID: v.ID, Name: v.Name, MatchResults: rds}
+	}
 	return res, nil
 }
 
+// defaultRecords returns an accepted result from the Catalogue of Life and
+// a synonym from another data-source. Results are limited to requested
+// data-sources. Unknown names have no results.
+func defaultRecords(v mlib.Match, input vlib.Input) []*vlib.ResultData {
+	if strings.HasPrefix(v.Name, "Nothing") {
+		return nil
+	}
+	res := []*vlib.ResultData{
+		{DataSourceID: 1, MatchedName: v.Name,
+			Curation: vlib.Curated, TaxonomicStatus: vlib.AcceptedTaxStatus},
+		{DataSourceID: 12, MatchedName: v.Name,
+			Curation: vlib.AutoCurated, TaxonomicStatus: vlib.SynonymTaxStatus},
+	}
+	if len(input.DataSources) == 0 {
+		return res
+	}
+	return slices.DeleteFunc(res, func(rd *vlib.ResultData) bool {
+		return !slices.Contains(input.DataSources, rd.DataSourceID)
+	})
+}
+
 func (m mockVerifier) NameByID(
 	nsi vlib.NameStringInput,
 ) (*verif.MatchRecord, error) {
@@ -58,26 +713,161 @@ func (m mockVerifier) NameByID(
 	return res, nil
 }
 
+func (m mockVerifier) NamesByIDs(
+	ctx context.Context,
+	ids []string,
+	dataSources []int,
+) (map[string]*verif.MatchRecord, error) {
+	res := make(map[string]*verif.MatchRecord)
+	bubo := gnuuid.New("Bubo bubo").String()
+	for _, id := range ids {
+		if id != bubo {
+			continue
+		}
+		res[id] = &verif.MatchRecord{
+			ID:   id,
+			Name: "Bubo bubo",
+			MatchResults: []*vlib.ResultData{
+				{DataSourceID: 1, MatchedName: "Bubo bubo"},
+				{DataSourceID: 12, MatchedName: "Bubo bubo"},
+			},
+		}
+	}
+	return res, nil
+}
+
+func (m mockVerifier) RecordsByIDs(
+	ctx context.Context,
+	dataSourceID int,
+	idType record.IDType,
+	ids []string,
+) (map[string][]*vlib.ResultData, error) {
+	res := make(map[string][]*vlib.ResultData)
+	for _, id := range ids {
+		if id != "3FDQ" {
+			continue
+		}
+		res[id] = []*vlib.ResultData{
+			{DataSourceID: dataSourceID, RecordID: id, MatchedName: "Bubo bubo"},
+		}
+	}
+	return res, nil
+}
+
+func (m mockVerifier) CanonicalRecords(
+	ctx context.Context,
+	id string,
+	kind canonical.Kind,
+	dataSources []int,
+	limit, offset int,
+) (string, []*vlib.ResultData, error) {
+	if id != gnuuid.New("Bubo bubo").String() {
+		return "", nil, nil
+	}
+	comb := "Bubo bubo (Linnaeus, 1758)"
+	orig := "Bubo bubo Linnaeus, 1758"
+	cl := "Animalia|Chordata|Aves|Strigiformes|Strigidae|Bubo"
+	rd := func(name string, dsID int, recID string) *vlib.ResultData {
+		return &vlib.ResultData{
+			DataSourceID:           dsID,
+			RecordID:               recID,
+			MatchedNameID:          gnuuid.New(name).String(),
+			MatchedName:            name,
+			MatchedCanonicalSimple: "Bubo bubo",
+			MatchedCanonicalFull:   "Bubo bubo",
+			MatchedCardinality:     2,
+			ClassificationPath:     cl,
+			MatchType:              vlib.Exact,
+		}
+	}
+	res := []*vlib.ResultData{
+		rd(comb, 1, "1a"),
+		rd(orig, 3, "3a"),
+		rd(comb, 12, "12a"),
+		rd(comb, 12, "12b"),
+	}
+	offset = min(offset, len(res))
+	return "Bubo bubo", res[offset:min(offset+limit, len(res))], nil
+}
+
 func (m mockVerifier) NameStringByID(s string) (string, error) {
 	return "", nil
 }
 
+func (m mockVerifier) NameStringsByIDs(
+	ctx context.Context,
+	ids []string,
+) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, v := range []string{"Bubo bubo", "Bubo bubo L.", "Nothing here"} {
+		id := gnuuid.New(v).String()
+		if slices.Contains(ids, id) {
+			res[id] = v
+		}
+	}
+	return res, nil
+}
+
 type mockVernacular struct{}
 
 func (mv mockVernacular) AddVernacularNames(
-	langs []string, names []vlib.Name,
-) ([]vlib.Name, error) {
-	return names, nil
+	ctx context.Context,
+	langs, countries []string,
+	names []vlib.Name,
+) (vern.Stats, error) {
+	return vern.Stats{}, nil
+}
+
+// SearchVernaculars returns a result from every data-source of the input,
+// or from data-sources 1 and 12.
+func (mv mockVernacular) SearchVernaculars(
+	ctx context.Context,
+	inp vern.SearchInput,
+) ([]vern.SearchResult, error) {
+	if inp.Query == "" {
+		return nil, nil
+	}
+	dss := inp.DataSources
+	if len(dss) == 0 {
+		dss = []int{1, 12}
+	}
+	res := make([]vern.SearchResult, len(dss))
+	for i, id := range dss {
+		res[i] = vern.SearchResult{DataSourceID: id, Name: "Bubo bubo"}
+	}
+	return res, nil
 }
 
-type mockMatcher struct{}
+type mockMatcher struct {
+	// fuzzyRelaxed makes all matches fuzzy with relaxed fuzzy matching.
+	fuzzyRelaxed bool
+
+	// calls counts matching requests, if it is given.
+	calls *atomic.Int32
+
+	// release blocks matching until it is closed, if it is given.
+	release chan struct{}
+}
 
 func (m mockMatcher) Init() error { return nil }
 
 func (m mockMatcher) MatchNames(names []string, opts ...gnmcfg.Option) mlib.Output {
+	if m.calls != nil {
+		m.calls.Add(1)
+	}
+	if m.release != nil {
+		<-m.release
+	}
+	cfg := gnmcfg.New()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	matches := make([]mlib.Match, len(names))
 	for i, name := range names {
 		matches[i] = mlib.Match{Name: name, ID: name}
+		if m.fuzzyRelaxed && cfg.WithRelaxedFuzzyMatch {
+			matches[i].MatchType = vlib.Fuzzy
+		}
 	}
 	return mlib.Output{Matches: matches}
 }
@@ -90,7 +880,7 @@ type mockFacet struct{}
 
 func (mf mockFacet) AdvancedSearch(
 	ctx context.Context,
-	inp search.Input,
+	inp srch.Input,
 ) (map[string]*verif.MatchRecord, error) {
 	var res map[string]*verif.MatchRecord
 	return res, nil
@@ -1,13 +1,22 @@
 package gnames
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/lexgroup"
 	"github.com/gnames/gnames/pkg/ent/score"
+	"github.com/gnames/gnames/pkg/ent/taxstats"
 	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
 	mlib "github.com/gnames/gnlib/ent/matcher"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 	gncfg "github.com/gnames/gnmatcher/pkg/config"
@@ -20,51 +29,310 @@ func (g gnames) DataSources(ids ...int) []*vlib.DataSource {
 	return g.vf.DataSources(ids...)
 }
 
+func (g gnames) DataSource(id string) (*vlib.DataSource, error) {
+	return g.vf.DataSource(id)
+}
+
+func (g gnames) FilterDataSources(f dsrc.Filter) []*vlib.DataSource {
+	return g.vf.FilterDataSources(f)
+}
+
+// ReloadDataSources updates metadata of data-sources from the database
+// and logs the changes.
+func (g gnames) ReloadDataSources(
+	ctx context.Context,
+) (dsrc.Changes, error) {
+	res, err := g.vf.ReloadDataSources(ctx)
+	if err != nil {
+		return res, fmt.Errorf("gnames.ReloadDataSources: %w", err)
+	}
+	if res.IsEmpty() {
+		slog.Info("Data-sources metadata did not change")
+		return res, nil
+	}
+	slog.Info("Data-sources metadata reloaded",
+		slog.Any("added", res.Added),
+		slog.Any("removed", res.Removed),
+		slog.Any("updated", res.Updated),
+	)
+	return res, nil
+}
+
+func (g gnames) DataSourceStats(
+	ctx context.Context,
+	id int,
+	refresh bool,
+) (dsrc.Stats, error) {
+	return g.vf.DataSourceStats(ctx, id, refresh)
+}
+
 func (g gnames) Verify(
 	ctx context.Context,
 	input vlib.Input,
 ) (vlib.Output, error) {
+	out, err := g.VerifyWithOptions(ctx, verif.Input{Input: input})
+	if err != nil {
+		return vlib.Output{}, err
+	}
+	res := vlib.Output{
+		Meta:  out.Meta.Meta,
+		Names: make([]vlib.Name, len(out.Names)),
+	}
+	for i := range out.Names {
+		res.Names[i] = out.Names[i].Name
+	}
+	return res, nil
+}
+
+func (g gnames) VerifyWithOptions(
+	ctx context.Context,
+	input verif.Input,
+) (verif.Output, error) {
 	var errString string
+	var vernStats vern.Stats
+
+	switch input.Sort {
+	case "":
+	case verif.SortConsensus:
+		input.WithConsensus = true
+	default:
+		return verif.Output{}, fmt.Errorf("gnames.VerifyWithOptions %q: %w",
+			input.Sort, verif.ErrSort)
+	}
 
-	// trim names input to 50 when vernaculars are given
-	if len(input.Vernaculars) > 0 && len(input.NameStrings) > 50 {
-		input.NameStrings = input.NameStrings[:50]
+	ids, err := g.dataSourceIDs(input.DataSources)
+	if err != nil {
+		return verif.Output{}, fmt.Errorf("gnames.VerifyWithOptions: %w", err)
+	}
+	input.Input.DataSources = append(input.Input.DataSources, ids...)
+
+	acc := access.FromContext(ctx)
+	withStatsDS := input.StatsDataSource != 0 &&
+		(input.WithStats || input.WithContextRanking)
+	if withStatsDS {
+		if err = g.checkStatsDataSource(acc, input.StatsDataSource); err != nil {
+			return verif.Output{}, fmt.Errorf("gnames.VerifyWithOptions: %w", err)
+		}
+	}
+
+	dss := input.Input.DataSources
+	if acc.IsRestricted() && len(dss) == 1 && dss[0] == 0 {
+		// all data-sources means all visible data-sources
+		dss = nil
+	}
+	dss, ok := acc.Restrict(dss)
+	if !ok {
+		return verif.Output{}, fmt.Errorf("gnames.VerifyWithOptions: %w", dsrc.ErrNotFound)
 	}
+	input.Input.DataSources = dss
 
-	namesRes := make([]vlib.Name, len(input.NameStrings))
+	namesRes := make([]verif.Name, len(input.NameStrings))
+	mrs := make([]*verif.MatchRecord, len(input.NameStrings))
 
-	matchRecords, matchOut, err := g.getMatchRecords(ctx, input)
+	matchRecords, matchOut, err := g.getMatchRecords(ctx, input.Input)
 	if err != nil {
 		// TODO fix this
 		errString = err.Error()
 	}
+	restrictRecords(acc, matchRecords)
 
 	for i, v := range matchOut.Matches {
-		if mr, ok := matchRecords[v.ID]; ok {
-			namesRes[i] = outputName(mr, input.WithAllMatches)
+		mrs[i] = matchRecords[v.ID]
+	}
+	statsMrs := mrs
+	if withStatsDS {
+		statsMrs, err = g.statsRecords(ctx, input, mrs)
+		if err != nil {
+			return verif.Output{}, fmt.Errorf("gnames.VerifyWithOptions: %w", err)
+		}
+	}
+	var contextTaxon string
+	var contextChanged []bool
+	if input.WithContextRanking {
+		contextTaxon, contextChanged = rankByContext(input, mrs, statsMrs)
+	}
+
+	for i, v := range matchOut.Matches {
+		if mr := mrs[i]; mr != nil {
+			namesRes[i].Name = outputName(mr, input.WithAllMatches)
+			if input.WithContextRanking {
+				namesRes[i].RankedByContext = contextChanged[i]
+			}
 			namesRes[i].Error = errString
+			if input.WithLexicalGroups {
+				namesRes[i].LexicalGroups = lexicalGroups(mr)
+			}
+			if input.WithConflicts {
+				namesRes[i].Conflicts = conflicts(mr)
+			}
+			if input.WithConsensus {
+				namesRes[i].Consensus = consensus(mr)
+			}
 			if input.WithCapitalization {
-				namesRes[i].Name = input.NameStrings[i]
-				namesRes[i].ID = gnuuid.New(namesRes[i].Name).String()
+				namesRes[i].Name.Name = input.NameStrings[i]
+				namesRes[i].ID = gnuuid.New(namesRes[i].Name.Name).String()
 			}
 		} else {
 			slog.Warn("Cannot find record for name", "name", v.Name)
 		}
 	}
 	if len(input.Vernaculars) > 0 {
-		namesRes, err = g.vern.AddVernacularNames(input.Vernaculars, namesRes)
+		vernStats, err = g.addVernaculars(ctx, input, namesRes, mrs)
 		if err != nil {
 			// TODO fix this
 			errString = err.Error()
 		}
 	}
-	res := vlib.Output{Meta: meta(input, namesRes), Names: namesRes}
+	if input.WithSuggestions {
+		if err = g.addSuggestions(ctx, input, namesRes); err != nil {
+			slog.Warn("Cannot find suggestions", "error", err)
+		}
+	}
+	var items []taxstats.Item
+	if input.WithStats {
+		items = bestStatsItems(namesRes)
+		if input.StatsDataSource != 0 {
+			items = statsItems(statsMrs, input.StatsDataSource)
+		}
+	}
+	if input.Sort == verif.SortConsensus {
+		sortByConsensus(namesRes)
+	}
+	res := verif.Output{Meta: meta(input, items), Names: namesRes}
+	res.ContextTaxon = contextTaxon
+	res.VernacularRecordsNum = vernStats.RecordsNum
+	res.VernacularChunksNum = vernStats.ChunksNum
+	return res, nil
+}
+
+// sortByConsensus orders names from the highest to the lowest support of
+// their consensus. Names with the same support keep their order.
+func sortByConsensus(names []verif.Name) {
+	support := func(n verif.Name) float64 {
+		if n.Consensus == nil {
+			return -1
+		}
+		return n.Consensus.Support
+	}
+	slices.SortStableFunc(names, func(a, b verif.Name) int {
+		return cmp.Compare(support(b), support(a))
+	})
+}
+
+// statsRecords returns match records that provide classification for
+// statistics and context ranking. If the data-source for statistics is
+// not among requested data-sources, names are matched against it
+// separately, so its records do not appear in results of names.
+func (g gnames) statsRecords(
+	ctx context.Context,
+	input verif.Input,
+	mrs []*verif.MatchRecord,
+) ([]*verif.MatchRecord, error) {
+	dss := input.Input.DataSources
+	if len(dss) == 0 || slices.Contains(dss, 0) ||
+		slices.Contains(dss, input.StatsDataSource) {
+		return mrs, nil
+	}
+
+	inp := input.Input
+	inp.DataSources = []int{input.StatsDataSource}
+	matchRecords, matchOut, err := g.getMatchRecords(ctx, inp)
+	if err != nil {
+		return nil, fmt.Errorf("gnames.statsRecords: %w", err)
+	}
+
+	res := make([]*verif.MatchRecord, len(input.NameStrings))
+	s := score.New()
+	for i, v := range matchOut.Matches {
+		if mr := matchRecords[v.ID]; mr != nil {
+			s.SortResults(mr)
+			res[i] = mr
+		}
+	}
+	return res, nil
+}
+
+// checkStatsDataSource checks if the data-source for statistics exists and
+// is visible to the client.
+func (g gnames) checkStatsDataSource(acc access.Access, id int) error {
+	_, err := g.vf.DataSource(strconv.Itoa(id))
+	if err != nil || !acc.Allowed(id) {
+		return fmt.Errorf("%d: %w", id, verif.ErrStatsDataSource)
+	}
+	return nil
+}
+
+// dataSourceIDs converts identifiers of data-sources to their IDs.
+// Identifiers of unknown data-sources return ErrNotFound. The `0` ID means
+// all data-sources and is used as is.
+func (g gnames) dataSourceIDs(idents dsrc.Identifiers) ([]int, error) {
+	res := make([]int, 0, len(idents))
+	for _, v := range idents {
+		if strings.TrimSpace(v) == "0" {
+			res = append(res, 0)
+			continue
+		}
+		ds, err := g.vf.DataSource(v)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ds.ID)
+	}
+	return res, nil
+}
+
+// addVernaculars finds vernacular names for all matched records of names,
+// not only for the ones that go to the output. It allows to merge
+// vernacular names from all data-sources that agree with the best match.
+func (g gnames) addVernaculars(
+	ctx context.Context,
+	input verif.Input,
+	names []verif.Name,
+	mrs []*verif.MatchRecord,
+) (vern.Stats, error) {
+	all := make([]vlib.Name, 0, len(mrs))
+	for _, mr := range mrs {
+		if mr != nil {
+			all = append(all, vlib.Name{Results: mr.MatchResults})
+		}
+	}
+	res, err := g.vern.AddVernacularNames(
+		ctx, input.Vernaculars, input.VernacularCountries, all,
+	)
+	if err != nil {
+		return res, fmt.Errorf("gnames.addVernaculars: %w", err)
+	}
+
+	for i := range names {
+		best := names[i].BestResult
+		if best == nil && len(names[i].Results) > 0 {
+			best = names[i].Results[0]
+		}
+		if best == nil || mrs[i] == nil {
+			continue
+		}
+		var rds []*vlib.ResultData
+		for _, rd := range mrs[i].MatchResults {
+			if rd == best || sameTaxon(rd, best) {
+				rds = append(rds, rd)
+			}
+		}
+		names[i].Vernaculars = vern.Merge(rds)
+	}
 	return res, nil
 }
 
+// sameTaxon checks if two results agree on the currently accepted name.
+func sameTaxon(rd1, rd2 *vlib.ResultData) bool {
+	return rd1.CurrentCanonicalSimple != "" &&
+		rd1.CurrentCanonicalSimple == rd2.CurrentCanonicalSimple
+}
+
 func outputName(mr *verif.MatchRecord, allMatches bool) vlib.Name {
 	s := score.New()
-	s.SortResults(mr)
+	if !mr.Sorted {
+		s.SortResults(mr)
+	}
 	item := vlib.Name{
 		ID:                 mr.ID,
 		Name:               mr.Name,
@@ -107,26 +375,79 @@ func outputName(mr *verif.MatchRecord, allMatches bool) vlib.Name {
 	return item
 }
 
-func meta(input vlib.Input, names []vlib.Name) vlib.Meta {
-	allSources := len(input.DataSources) == 1 && input.DataSources[0] == 0
-	hs := make([]stats.Hierarchy, 0, len(names))
+// statsItems selects the best result of the data-source used for
+// statistics for every unique name. The Catalogue of Life is used if the
+// data-source is not given. Names without results from the data-source
+// are skipped.
+func statsItems(mrs []*verif.MatchRecord, dataSourceID int) []taxstats.Item {
+	if dataSourceID == 0 {
+		dataSourceID = 1
+	}
+	res := make([]taxstats.Item, 0, len(mrs))
 	ids := make(map[string]struct{})
-	for i := range names {
-		if _, ok := ids[names[i].ID]; ok {
+	for _, mr := range mrs {
+		if mr == nil {
+			continue
+		}
+		if _, ok := ids[mr.ID]; ok {
+			continue
+		}
+		// results are sorted by score already
+		idx := slices.IndexFunc(mr.MatchResults, func(rd *vlib.ResultData) bool {
+			return rd.DataSourceID == dataSourceID
+		})
+		if idx == -1 {
+			continue
+		}
+
+		ids[mr.ID] = struct{}{}
+		res = append(res, taxstats.Item{
+			ID:     mr.ID,
+			Name:   mr.Name,
+			Result: mr.MatchResults[idx],
+		})
+	}
+	return res
+}
+
+// bestStatsItems selects names which best results come from the Catalogue
+// of Life. It is the default selection of names for statistics.
+func bestStatsItems(names []verif.Name) []taxstats.Item {
+	res := make([]taxstats.Item, 0, len(names))
+	ids := make(map[string]struct{})
+	for _, v := range names {
+		if _, ok := ids[v.ID]; ok {
 			continue
 		}
-		if names[i].BestResult == nil || names[i].BestResult.DataSourceID != 1 {
+		if v.BestResult == nil || v.BestResult.DataSourceID != 1 {
 			continue
 		}
 
-		ids[names[i].ID] = struct{}{}
-		hs = append(hs, names[i])
+		ids[v.ID] = struct{}{}
+		res = append(res, taxstats.Item{
+			ID:     v.ID,
+			Name:   v.Name.Name,
+			Result: v.BestResult,
+		})
 	}
+	return res
+}
+
+func meta(input verif.Input, items []taxstats.Item) verif.Meta {
+	dss := input.Input.DataSources
+	allSources := len(dss) == 1 && dss[0] == 0
 	var c stats.Stats
 	var ks []vlib.Kingdom
+	var ranks []taxstats.RankDist
+	var outliers []taxstats.Outlier
+	var outliersNum int
+	var statsDS int
 
 	if input.WithStats {
-		c = stats.New(hs, input.MainTaxonThreshold)
+		statsDS = cmp.Or(input.StatsDataSource, 1)
+		c = stats.New(taxstats.Hierarchies(items), input.MainTaxonThreshold)
+		ranks = taxstats.Distribution(items)
+		outliers, outliersNum = taxstats.Outliers(items, c.MainTaxon.Name)
 		ks = make([]vlib.Kingdom, len(c.Kingdoms))
 		for i, v := range c.Kingdoms {
 			ks[i] = vlib.Kingdom{
@@ -147,15 +468,57 @@ func meta(input vlib.Input, names []vlib.Name) vlib.Meta {
 		WithRelaxedFuzzyMatch:   input.WithRelaxedFuzzyMatch,
 		WithUninomialFuzzyMatch: input.WithUninomialFuzzyMatch,
 		MainTaxonThreshold:      input.MainTaxonThreshold,
-		DataSources:             input.DataSources,
+		DataSources:             dss,
 		MainTaxon:               c.MainTaxon.Name,
 		MainTaxonPercentage:     c.MainTaxonPercentage,
-		StatsNamesNum:           len(hs),
+		StatsNamesNum:           len(items),
 		Kingdom:                 c.Kingdom.Name,
 		KingdomPercentage:       c.KingdomPercentage,
 		Kingdoms:                ks,
 	}
-	return res
+	return verif.Meta{
+		Meta:                res,
+		VernacularCountries: input.VernacularCountries,
+		WithLexicalGroups:   input.WithLexicalGroups,
+		WithConflicts:       input.WithConflicts,
+		WithConsensus:       input.WithConsensus,
+		WithContextRanking:  input.WithContextRanking,
+		WithSuggestions:     input.WithSuggestions,
+		Sort:                input.Sort,
+		StatsDataSource:     statsDS,
+		Ranks:               ranks,
+		Outliers:            outliers,
+		OutliersNum:         outliersNum,
+	}
+}
+
+// lexicalGroups groups all matched results of a name into lexical groups,
+// even if only the best result goes to the output.
+func lexicalGroups(mr *verif.MatchRecord) []verif.LexicalGroup {
+	lgs := lexgroup.NameToLexicalGroups(outputName(mr, true))
+	return verif.NewLexicalGroups(lgs)
+}
+
+// conflicts compares all matched results of a name. It returns nil if
+// the name has no matches.
+func conflicts(mr *verif.MatchRecord) *conflict.Report {
+	name := outputName(mr, true)
+	if len(name.Results) == 0 {
+		return nil
+	}
+	res := conflict.New(name.Results)
+	return &res
+}
+
+// consensus finds the consensus of data-sources about a name. It returns
+// nil if the name has no matches.
+func consensus(mr *verif.MatchRecord) *conflict.Consensus {
+	name := outputName(mr, true)
+	if len(name.Results) == 0 {
+		return nil
+	}
+	res := conflict.NewConsensus(name.Results)
+	return &res
 }
 
 func (g gnames) getMatchRecords(
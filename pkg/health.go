@@ -0,0 +1,77 @@
+package gnames
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gnames/gnames/pkg/ent/admin"
+)
+
+// canaryName is matched to check that the matcher works.
+const canaryName = "Homo sapiens"
+
+// Readiness checks the database, data-sources metadata and the matcher.
+func (g gnames) Readiness(ctx context.Context) admin.Readiness {
+	res := admin.Readiness{
+		Ready: true,
+		Components: []admin.Component{
+			check("database", func() error {
+				return g.vf.Ping(ctx)
+			}),
+			check("dataSources", func() error {
+				if len(g.vf.DataSources()) == 0 {
+					return errors.New("no data-sources metadata")
+				}
+				return nil
+			}),
+			check("matcher", func() error {
+				return g.pingMatcher(ctx)
+			}),
+		},
+	}
+	for _, c := range res.Components {
+		res.Ready = res.Ready && c.Ready
+	}
+	return res
+}
+
+// pingMatcher runs a canary match. The embedded matcher is not called
+// until its initialisation is finished. The match cannot be cancelled,
+// so only one canary match runs at a time, and checks that time out do
+// not leave more and more of them behind.
+func (g gnames) pingMatcher(ctx context.Context) error {
+	if in, ok := g.matcher.(admin.Initializer); ok {
+		if err := in.InitErr(); err != nil {
+			return err
+		}
+	}
+
+	ch := g.canary.DoChan(canaryName, func() (any, error) {
+		return len(g.matcher.MatchNames([]string{canaryName}).Matches), nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		if res.Val.(int) != 1 {
+			return errors.New("matcher did not return results")
+		}
+		return nil
+	}
+}
+
+// check runs a readiness check and measures its duration.
+func check(name string, f func() error) admin.Component {
+	start := time.Now()
+	err := f()
+	res := admin.Component{
+		Name:      name,
+		Ready:     err == nil,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -4,6 +4,14 @@ import (
 	"context"
 
 	"github.com/gnames/gnames/pkg/config"
+	"github.com/gnames/gnames/pkg/ent/admin"
+	"github.com/gnames/gnames/pkg/ent/canonical"
+	"github.com/gnames/gnames/pkg/ent/conflict"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/gnames/gnames/pkg/ent/srch"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	"github.com/gnames/gnames/pkg/ent/vern"
 	"github.com/gnames/gnlib/ent/gnvers"
 	"github.com/gnames/gnlib/ent/reconciler"
 	"github.com/gnames/gnlib/ent/verifier"
@@ -20,10 +28,16 @@ type GNames interface {
 	// returns back results of verification.
 	Verify(ctx context.Context, params verifier.Input) (verifier.Output, error)
 
+	// VerifyWithOptions is Verify with options that are specific to gnames,
+	// such as vernacular countries, statistics data-source, lexical groups,
+	// conflicts and suggestions. Its output keeps the results of these
+	// options.
+	VerifyWithOptions(ctx context.Context, params verif.Input) (verif.Output, error)
+
 	// Reconcile takes the result of verification and converts it into
 	// lexical reconciliation groups.
 	Reconcile(
-		verif verifier.Output,
+		verified verif.Output,
 		qs map[string]reconciler.Query,
 		ids []string,
 	) reconciler.Output
@@ -32,6 +46,7 @@ type GNames interface {
 	// Reconciliation Service API and returns back the
 	// result according to the API corresponding schema.
 	ExtendReconcile(
+		context.Context,
 		reconciler.ExtendQuery,
 	) (reconciler.ExtendOutput, error)
 
@@ -39,19 +54,97 @@ type GNames interface {
 	// information. For example, it can handle cases where the genus is
 	// abbreviated or only part of the specific epithet is known.
 	// It can also utilize year and year range information to narrow
-	// down the search.
-	Search(ctx context.Context, srch search.Input) search.Output
+	// down the search. Species epithets and authors can be matched fuzzily
+	// if the input sets MaxEditDist.
+	Search(ctx context.Context, inp srch.Input) search.Output
+
+	// SearchVernaculars finds scientific names by their vernacular names.
+	// The search ignores case and diacritics, it can be restricted to
+	// particular languages and can match beginnings of vernacular names.
+	SearchVernaculars(ctx context.Context, inp vern.SearchInput) vern.SearchOutput
 
 	// NameByID finds a name-string according to its UUID or exact spelling.
 	// The boolean argument allows to return not only identical strings, but
 	// all strings that match name-string connected to the ID.
-	NameByID(verifier.NameStringInput, bool) (verifier.NameStringOutput, error)
+	NameByID(
+		context.Context,
+		verifier.NameStringInput,
+		bool,
+	) (verifier.NameStringOutput, error)
+
+	// NameStrings finds many name-strings by their UUIDs or exact
+	// spellings at once. Results follow the order of the input, name-strings
+	// without records are marked as not found.
+	NameStrings(
+		context.Context,
+		verif.NameStringsInput,
+	) (verif.NameStringsOutput, error)
+
+	// Records finds names by identifiers of records of a data-source.
+	// Identifiers can be record IDs, local IDs or global IDs. Results follow
+	// the order of the input, identifiers without records are marked as
+	// not found.
+	Records(context.Context, record.Input) (record.Output, error)
+
+	// Canonical lists all name-strings that share a canonical form. The
+	// canonical form is given by its UUID, or by a name. Name-strings are
+	// grouped into lexical groups that separate authorship variants.
+	Canonical(context.Context, canonical.Input) (canonical.Output, error)
+
+	// Conflicts verifies a name and reports disagreements of curated
+	// data-sources about its taxonomic status, currently accepted name and
+	// classification.
+	Conflicts(context.Context, conflict.Input) (conflict.Output, error)
 
 	// Datasources take IDs of data-sourses and return back list of
 	// corresponding metadata. If no IDs are given, it returns metadata for all
 	// data-sources.
 	DataSources(ids ...int) []*verifier.DataSource
 
+	// DataSource finds metadata of a data-source by its ID, UUID or short
+	// title (case-insensitive). It returns dsrc.ErrNotFound for unknown
+	// data-sources.
+	DataSource(id string) (*verifier.DataSource, error)
+
+	// FilterDataSources returns metadata of data-sources that satisfy the
+	// filter: a text in titles, descriptions or citations, curation levels,
+	// presence of taxonomic data.
+	FilterDataSources(f dsrc.Filter) []*verifier.DataSource
+
+	// ReloadDataSources reads metadata of data-sources from the database
+	// again, so new data-sources and changes in metadata become available
+	// without a restart. It returns IDs of added, removed and updated
+	// data-sources.
+	ReloadDataSources(ctx context.Context) (dsrc.Changes, error)
+
+	// DataSourceStats returns statistics computed from records of a
+	// data-source: numbers of name-strings, accepted names and synonyms,
+	// vernacular names by language, ranks and kingdoms. The results are
+	// cached, refresh flag makes them recomputed.
+	DataSourceStats(ctx context.Context, id int, refresh bool) (dsrc.Stats, error)
+
+	// Readiness checks that the database, data-sources metadata and the
+	// matcher are able to serve requests and reports the status and the
+	// latency of every check.
+	Readiness(ctx context.Context) admin.Readiness
+
+	// AdminStatus returns the runtime state of the service: caches of the
+	// embedded matcher, the database connections pool, the number of
+	// data-sources and cached results.
+	AdminStatus() (admin.Status, error)
+
+	// RebuildMatcherCache recreates caches of the embedded matcher. It
+	// returns ErrRemoteMatcher if a remote matcher is used.
+	RebuildMatcherCache() error
+
+	// FlushDataSourceStats removes cached statistics of data-sources and
+	// returns their number.
+	FlushDataSourceStats() int
+
+	// Close releases resources of the matcher. It should be called when
+	// the service stops.
+	Close() error
+
 	// GetConfig returns configuration of the GNames object.
 	GetConfig() config.Config
 
@@ -4,27 +4,43 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/verif"
 	vlib "github.com/gnames/gnlib/ent/verifier"
+	"github.com/gnames/gnuuid"
+	"github.com/google/uuid"
 )
 
 func (g gnames) NameByID(
+	ctx context.Context,
 	params vlib.NameStringInput,
 	fullMatch bool,
 ) (vlib.NameStringOutput, error) {
 	if fullMatch {
-		return g.matchIDByName(params)
+		return g.matchIDByName(ctx, params)
 	} else {
-		return g.matchID(params)
+		return g.matchID(ctx, params)
 	}
 }
 
-func (g gnames) matchID(params vlib.NameStringInput) (vlib.NameStringOutput, error) {
+func (g gnames) matchID(
+	ctx context.Context,
+	params vlib.NameStringInput,
+) (vlib.NameStringOutput, error) {
 
 	var res vlib.NameStringOutput
+	acc := access.FromContext(ctx)
+	dss, ok := acc.Restrict(params.DataSources)
+	if !ok {
+		return res, fmt.Errorf("gnames.matchID: %w", dsrc.ErrNotFound)
+	}
+	params.DataSources = dss
 	mr, err := g.vf.NameByID(params)
 	if err != nil {
 		return res, fmt.Errorf("gnames.matchID: %w", err)
 	}
+	restrictRecord(acc, mr)
 	meta := vlib.NameStringMeta{
 		ID:             params.ID,
 		DataSources:    params.DataSources,
@@ -41,20 +57,23 @@ func (g gnames) matchID(params vlib.NameStringInput) (vlib.NameStringOutput, err
 	return res, nil
 }
 
-func (g gnames) matchIDByName(params vlib.NameStringInput) (vlib.NameStringOutput, error) {
+func (g gnames) matchIDByName(
+	ctx context.Context,
+	params vlib.NameStringInput,
+) (vlib.NameStringOutput, error) {
 	var res vlib.NameStringOutput
 	name, err := g.vf.NameStringByID(params.ID)
 	if err != nil {
 		return res, fmt.Errorf("gnames.matchIDByName: %w", err)
 	}
-	input := vlib.Input{
+	input := verif.Input{Input: vlib.Input{
 		NameStrings:      []string{name},
 		DataSources:      params.DataSources,
 		WithAllMatches:   true,
 		WithSpeciesGroup: true,
-	}
-	var out vlib.Output
-	out, err = g.Verify(context.Background(), input)
+	}}
+	var out verif.Output
+	out, err = g.VerifyWithOptions(ctx, input)
 	if err != nil && len(out.Names) == 0 {
 		return res, fmt.Errorf("gnames.matchIDByName: %w", err)
 	}
@@ -64,8 +83,68 @@ func (g gnames) matchIDByName(params vlib.NameStringInput) (vlib.NameStringOutpu
 			DataSources:    params.DataSources,
 			WithAllMatches: true,
 		},
-		Name: &out.Names[0],
+		Name: &out.Names[0].Name,
 	}
 	return res, nil
 
 }
+
+func (g gnames) NameStrings(
+	ctx context.Context,
+	inp verif.NameStringsInput,
+) (verif.NameStringsOutput, error) {
+	res := verif.NameStringsOutput{
+		NameStringsMeta: verif.NameStringsMeta{
+			DataSources:       inp.DataSources,
+			WithAllMatches:    inp.WithAllMatches,
+			WithLexicalGroups: inp.WithLexicalGroups,
+			NamesNum:          len(inp.IDs),
+		},
+		Names: make([]verif.NameString, len(inp.IDs)),
+	}
+
+	acc := access.FromContext(ctx)
+	dss, ok := acc.Restrict(inp.DataSources)
+	if !ok {
+		return res, fmt.Errorf("gnames.NameStrings: %w", dsrc.ErrNotFound)
+	}
+
+	var ids []string
+	seen := make(map[string]struct{}, len(inp.IDs))
+	for i, v := range inp.IDs {
+		id := v
+		if _, err := uuid.Parse(v); err != nil {
+			id = gnuuid.New(v).String()
+		}
+		res.Names[i] = verif.NameString{Input: v, ID: id}
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	mrs, err := g.vf.NamesByIDs(ctx, ids, dss)
+	if err != nil {
+		return res, fmt.Errorf("gnames.NameStrings: %w", err)
+	}
+	restrictRecords(acc, mrs)
+
+	names := make(map[string]*vlib.Name, len(mrs))
+	for id, mr := range mrs {
+		name := outputName(mr, inp.WithAllMatches)
+		names[id] = &name
+	}
+	for i := range res.Names {
+		id := res.Names[i].ID
+		res.Names[i].Name = names[id]
+		if res.Names[i].Name == nil {
+			res.Names[i].NotFound = true
+			res.NotFoundNum++
+			continue
+		}
+		if inp.WithLexicalGroups {
+			res.Names[i].LexicalGroups = lexicalGroups(mrs[id])
+		}
+	}
+	return res, nil
+}
@@ -6,20 +6,21 @@ import (
 
 	"github.com/gnames/gnames/pkg/ent/lexgroup"
 	"github.com/gnames/gnames/pkg/ent/recon"
+	"github.com/gnames/gnames/pkg/ent/verif"
 	"github.com/gnames/gnlib/ent/reconciler"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 )
 
 func (g gnames) Reconcile(
-	verif vlib.Output,
+	verified verif.Output,
 	qs map[string]reconciler.Query,
 	ids []string,
 ) reconciler.Output {
 	res := reconciler.Output(make(map[string]reconciler.ReconciliationResult))
 
-	for i, v := range verif.Names {
+	for i, v := range verified.Names {
 		prs := qs[ids[i]].Properties
-		lgs := lexgroup.NameToLexicalGroups(v)
+		lgs := lexgroup.NameToLexicalGroups(v.Name)
 		lgs = filterLexGrpByProperties(lgs, prs)
 		var rcs []reconciler.ReconciliationCandidate
 
@@ -0,0 +1,64 @@
+package gnames
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/dsrc"
+	"github.com/gnames/gnames/pkg/ent/record"
+	"github.com/gnames/gnames/pkg/ent/vern"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+func (g gnames) Records(
+	ctx context.Context,
+	inp record.Input,
+) (record.Output, error) {
+	res := record.Output{
+		Meta: record.Meta{
+			DataSourceID: inp.DataSourceID,
+			IDType:       inp.IDType,
+			Vernaculars:  inp.Vernaculars,
+			RecordsNum:   len(inp.IDs),
+		},
+		Records: make([]record.Record, len(inp.IDs)),
+	}
+
+	acc := access.FromContext(ctx)
+	_, err := g.vf.DataSource(strconv.Itoa(inp.DataSourceID))
+	if err != nil || !acc.Allowed(inp.DataSourceID) {
+		return res, fmt.Errorf("gnames.Records: data-source %d: %w",
+			inp.DataSourceID, dsrc.ErrNotFound)
+	}
+
+	rdsMap, err := g.vf.RecordsByIDs(ctx, inp.DataSourceID, inp.IDType, inp.IDs)
+	if err != nil {
+		return res, fmt.Errorf("gnames.Records: %w", err)
+	}
+
+	if len(inp.Vernaculars) > 0 && len(rdsMap) > 0 {
+		var all []vlib.Name
+		for _, rds := range rdsMap {
+			all = append(all, vlib.Name{Results: rds})
+		}
+		_, err = g.vern.AddVernacularNames(ctx, inp.Vernaculars, nil, all)
+		if err != nil {
+			return res, fmt.Errorf("gnames.Records: %w", err)
+		}
+	}
+
+	for i, id := range inp.IDs {
+		res.Records[i].ID = id
+		rds, ok := rdsMap[id]
+		if !ok {
+			res.Records[i].NotFound = true
+			res.NotFoundNum++
+			continue
+		}
+		res.Records[i].Results = rds
+		res.Records[i].Vernaculars = vern.Merge(rds)
+	}
+	return res, nil
+}
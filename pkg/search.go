@@ -1,10 +1,14 @@
 package gnames
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
+	"maps"
 	"slices"
 
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/srch"
 	"github.com/gnames/gnames/pkg/ent/verif"
 	vlib "github.com/gnames/gnlib/ent/verifier"
 	"github.com/gnames/gnquery/ent/search"
@@ -14,20 +18,31 @@ import (
 // information. For example, it can handle cases where the genus is
 // abbreviated or only part of the specific epithet is known.
 // It can also utilize year and year range information to narrow
-// down the search.
+// down the search. Species epithets and authors can be matched fuzzily
+// if the input sets MaxEditDist.
 func (g gnames) Search(
 	ctx context.Context,
-	input search.Input,
+	input srch.Input,
 ) search.Output {
 	input.Query = input.ToQuery()
-	slog.Info("Search", "query", input.Query)
+	slog.Info("Search",
+		"query", input.Query,
+		"maxEditDist", input.MaxEditDist,
+	)
 
-	res := search.Output{Meta: search.Meta{Input: input}}
+	res := search.Output{Meta: search.Meta{Input: input.Input}}
 	matchRecords, err := g.sr.AdvancedSearch(ctx, input)
 	if err != nil {
 		// TODO fix this
 		res.Error = err.Error()
 	}
+	acc := access.FromContext(ctx)
+	restrictRecords(acc, matchRecords)
+	if acc.IsRestricted() {
+		maps.DeleteFunc(matchRecords, func(_ string, mr *verif.MatchRecord) bool {
+			return len(mr.MatchResults) == 0
+		})
+	}
 	res.NamesNumber = len(matchRecords)
 
 	sortedNames := sortNames(matchRecords)
@@ -42,6 +57,9 @@ func (g gnames) Search(
 	return res
 }
 
+// sortNames sorts keys of match records so that names with the
+// smallest edit distance go first, and names with the same edit
+// distance are sorted alphabetically.
 func sortNames(mrs map[string]*verif.MatchRecord) []string {
 	res := make([]string, len(mrs))
 	var count int
@@ -49,6 +67,22 @@ func sortNames(mrs map[string]*verif.MatchRecord) []string {
 		res[count] = k
 		count++
 	}
-	slices.Sort(res)
+	slices.SortFunc(res, func(a, b string) int {
+		edA, edB := minEditDistance(mrs[a]), minEditDistance(mrs[b])
+		if edA != edB {
+			return cmp.Compare(edA, edB)
+		}
+		return cmp.Compare(a, b)
+	})
+	return res
+}
+
+func minEditDistance(mr *verif.MatchRecord) int {
+	var res int
+	for i, v := range mr.MatchResults {
+		if i == 0 || v.EditDistance < res {
+			res = v.EditDistance
+		}
+	}
 	return res
 }
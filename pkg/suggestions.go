@@ -0,0 +1,69 @@
+package gnames
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/verif"
+	vlib "github.com/gnames/gnlib/ent/verifier"
+)
+
+const (
+	// suggestionsNum is the default number of suggestions for a name.
+	suggestionsNum = 5
+
+	// maxSuggestionsNum is the largest number of suggestions for a name.
+	maxSuggestionsNum = 20
+)
+
+// addSuggestions matches names without matches again with relaxed fuzzy
+// matching of all names and uninomials, and adds found candidates to
+// the names as suggestions. Candidates are searched in all visible
+// data-sources, so suggestions are found even if relaxed matching was
+// used already.
+func (g gnames) addSuggestions(
+	ctx context.Context,
+	input verif.Input,
+	names []verif.Name,
+) error {
+	var idx []int
+	var nameStrings []string
+	for i := range names {
+		if names[i].MatchType == vlib.NoMatch {
+			idx = append(idx, i)
+			nameStrings = append(nameStrings, input.NameStrings[i])
+		}
+	}
+	if len(idx) == 0 {
+		return nil
+	}
+
+	inp := input.Input
+	inp.NameStrings = nameStrings
+	inp.WithRelaxedFuzzyMatch = true
+	inp.WithUninomialFuzzyMatch = true
+	acc := access.FromContext(ctx)
+	inp.DataSources = slices.Clone(acc.DataSources)
+	mrs, matchOut, err := g.getMatchRecords(ctx, inp)
+	if err != nil {
+		return fmt.Errorf("gnames.addSuggestions: %w", err)
+	}
+	restrictRecords(acc, mrs)
+
+	num := input.SuggestionsNum
+	if num <= 0 {
+		num = suggestionsNum
+	}
+	num = min(num, maxSuggestionsNum)
+	for i, v := range matchOut.Matches {
+		mr, ok := mrs[v.ID]
+		if !ok || i >= len(idx) {
+			continue
+		}
+		rds := outputName(mr, true).Results
+		names[idx[i]].Suggestions = verif.NewSuggestions(rds, num)
+	}
+	return nil
+}
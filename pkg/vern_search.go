@@ -0,0 +1,36 @@
+package gnames
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gnames/gnames/pkg/ent/access"
+	"github.com/gnames/gnames/pkg/ent/vern"
+)
+
+// SearchVernaculars finds scientific names by their vernacular names.
+// The search is case- and accent-insensitive, and can be limited to
+// particular languages.
+func (g gnames) SearchVernaculars(
+	ctx context.Context,
+	input vern.SearchInput,
+) vern.SearchOutput {
+	slog.Info("Vernacular search",
+		"query", input.Query,
+		"languages", input.Languages,
+	)
+
+	res := vern.SearchOutput{SearchMeta: vern.SearchMeta{Input: input}}
+	input.DataSources, _ = access.FromContext(ctx).Restrict(nil)
+	names, err := g.vern.SearchVernaculars(ctx, input)
+	if err != nil {
+		slog.Error("Cannot search vernacular names", "error", err)
+		res.Error = err.Error()
+	}
+	if names == nil {
+		names = []vern.SearchResult{}
+	}
+	res.NamesNumber = len(names)
+	res.Names = names
+	return res
+}